@@ -1,31 +1,31 @@
 package main
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"net/http"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
 	"time"
 
+	"github.com/ReyviRahman/to-backend/internal/config"
+	"github.com/ReyviRahman/to-backend/internal/ratelimit"
 	"github.com/ReyviRahman/to-backend/internal/store"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"go.uber.org/zap"
 )
 
-type config struct {
-	addr string
-	db   dbConfig
-}
-
-type dbConfig struct {
-	addr         string
-	maxOpenConns int
-	maxIdleConns int
-	maxIdleTime  string
-}
-
 type application struct {
-	config config
-	store  store.Storage
-	logger *zap.SugaredLogger
+	config       config.Config
+	db           *sql.DB
+	store        store.Storage
+	logger       *zap.SugaredLogger
+	rateLimiter  ratelimit.Limiter
+	shuttingDown atomic.Bool
 }
 
 func (app *application) mount() http.Handler {
@@ -33,19 +33,51 @@ func (app *application) mount() http.Handler {
 
 	r.Use(middleware.RequestID)
 	r.Use(middleware.RealIP)
-	r.Use(middleware.Logger)
-	r.Use(middleware.Recoverer)
+	r.Use(app.tracingMiddleware)
+	r.Use(app.accessLog)
+	r.Use(app.metricsMiddleware)
+	r.Use(app.localeMiddleware)
+	r.Use(app.recoverPanic)
+	r.NotFound(app.routeNotFoundResponse)
+	r.MethodNotAllowed(app.methodNotAllowedResponse)
 
-	r.Get("/", func(w http.ResponseWriter, r *http.Request) {
-		w.Write([]byte("api berjalan"))
-	})
+	// Probe dan scrape dari orchestrator tidak ikut dibatasi rate limiter
+	r.Get("/healthz", app.healthzHandler)
+	r.Get("/readyz", app.readyzHandler)
+	r.Handle("/metrics", promhttp.Handler())
+
+	r.Group(func(r chi.Router) {
+		r.Use(app.rateLimit("global", app.config.RateLimit.Global))
 
-	r.Route("/questions", func(r chi.Router) {
-		r.Post("/", app.createQuestionHandler)
-		r.Get("/", app.getQuestionHandler)
-		r.Route("/{id}", func(r chi.Router) {
-			r.Put("/", app.updateQuestionHandler)
-			r.Delete("/", app.deleteQuestionHandler)
+		r.Get("/", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte("api berjalan"))
+		})
+		r.Get("/openapi.json", app.openAPIHandler)
+		r.Get("/docs", app.docsHandler)
+
+		r.Route("/questions", func(r chi.Router) {
+			r.Post("/", app.createQuestionHandler)
+			r.Get("/", app.getQuestionHandler)
+			r.Route("/{id}", func(r chi.Router) {
+				r.Put("/", app.updateQuestionHandler)
+				r.Delete("/", app.deleteQuestionHandler)
+				r.Patch("/status", app.updateQuestionStatusHandler)
+				r.Get("/review-comments", app.getReviewCommentsHandler)
+				r.Post("/review-comments", app.createReviewCommentHandler)
+				r.With(app.rateLimit("reports", app.config.RateLimit.Strict)).Post("/reports", app.createReportHandler)
+				r.Get("/comments", app.getCommentsHandler)
+				r.With(app.rateLimit("comments", app.config.RateLimit.Strict)).Post("/comments", app.createCommentHandler)
+			})
+		})
+
+		r.Route("/comments/{id}", func(r chi.Router) {
+			r.Patch("/", app.moderateCommentHandler)
+			r.Delete("/", app.deleteCommentHandler)
+		})
+
+		r.Route("/reports", func(r chi.Router) {
+			r.Get("/", app.getReportsHandler)
+			r.Patch("/{id}", app.updateReportHandler)
 		})
 	})
 
@@ -54,14 +86,52 @@ func (app *application) mount() http.Handler {
 
 func (app *application) run(mux http.Handler) error {
 	srv := &http.Server{
-		Addr:         app.config.addr,
+		Addr:         app.config.Addr,
 		Handler:      mux,
 		WriteTimeout: time.Second * 30,
 		ReadTimeout:  time.Second * 10,
 		IdleTimeout:  time.Minute,
 	}
 
+	shutdown := make(chan error)
+
+	sigCtx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		<-sigCtx.Done()
+
+		// Kembalikan perilaku default supaya signal kedua langsung mematikan
+		// proses, misalnya saat operator tidak mau menunggu drain dan shutdown
+		stop()
+
+		app.logger.Infow("shutting down server", "timeout", app.config.Shutdown.Timeout.String())
+
+		app.shuttingDown.Store(true)
+		if app.config.Shutdown.DrainDelay > 0 {
+			app.logger.Infow("readiness disabled, draining traffic", "delay", app.config.Shutdown.DrainDelay.String())
+			time.Sleep(app.config.Shutdown.DrainDelay)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), app.config.Shutdown.Timeout)
+		defer cancel()
+
+		// Shutdown berhenti menerima koneksi baru lalu menunggu request yang sedang berjalan selesai
+		shutdown <- srv.Shutdown(ctx)
+	}()
+
 	app.logger.Infow("server has started", "addr", srv.Addr)
 
-	return srv.ListenAndServe()
+	err := srv.ListenAndServe()
+	if !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	if err := <-shutdown; err != nil {
+		return err
+	}
+
+	app.logger.Infow("server has stopped", "addr", srv.Addr)
+
+	return nil
 }
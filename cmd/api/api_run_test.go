@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bufio"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// TestRunSecondSignalExits menjalankan server di proses terpisah (test binary
+// yang sama) karena signal kedua memang harus mematikan seluruh proses
+func TestRunSecondSignalExits(t *testing.T) {
+	if os.Getenv("RUN_SERVER_HELPER") == "1" {
+		runServerHelper(t)
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestRunSecondSignalExits$")
+	cmd.Env = append(os.Environ(), "RUN_SERVER_HELPER=1")
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cmd.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { cmd.Process.Kill() })
+
+	lines := bufio.NewScanner(stdout)
+	waitFor := func(msg string) {
+		t.Helper()
+		for lines.Scan() {
+			if strings.Contains(lines.Text(), msg) {
+				return
+			}
+		}
+		t.Fatalf("helper exited before logging %q", msg)
+	}
+
+	waitFor("server has started")
+	cmd.Process.Signal(syscall.SIGTERM)
+	waitFor("shutting down server")
+
+	// Server sedang menunggu drain delay satu menit; signal kedua harus langsung mematikannya
+	start := time.Now()
+	cmd.Process.Signal(syscall.SIGTERM)
+	cmd.Wait()
+
+	if cmd.ProcessState.ExitCode() != -1 {
+		t.Errorf("helper exited with code %d, want it killed by the second signal", cmd.ProcessState.ExitCode())
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("helper took %s to exit after the second signal", elapsed)
+	}
+}
+
+func runServerHelper(t *testing.T) {
+	app := newTestApplication(t)
+	app.logger = zap.NewExample().Sugar()
+	app.config.Addr = "127.0.0.1:0"
+	app.config.Shutdown.DrainDelay = time.Minute
+	app.config.Shutdown.Timeout = time.Minute
+
+	if err := app.run(app.mount()); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestRootAndDocsRoutes(t *testing.T) {
+	h := newTestApplication(t).mount()
+
+	tests := []struct {
+		target      string
+		contentType string
+		contains    string
+	}{
+		{"/", "", "api berjalan"},
+		{"/docs", "text/html; charset=utf-8", "/openapi.json"},
+		{"/metrics", "text/plain", "go_goroutines"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.target, func(t *testing.T) {
+			rec := doRequest(t, h, http.MethodGet, tt.target, "")
+			assertStatus(t, rec, http.StatusOK)
+
+			if !strings.HasPrefix(rec.Header().Get("Content-Type"), tt.contentType) {
+				t.Errorf("Content-Type = %q, want %q", rec.Header().Get("Content-Type"), tt.contentType)
+			}
+			if !strings.Contains(rec.Body.String(), tt.contains) {
+				t.Errorf("body does not contain %q", tt.contains)
+			}
+		})
+	}
+}
+
+// Halaman docs harus memuat Swagger UI dari versi yang dipin dan
+// mengirim CSP yang hanya mengizinkan path versi tersebut
+func TestDocsPinsSwaggerUI(t *testing.T) {
+	h := newTestApplication(t).mount()
+
+	rec := doRequest(t, h, http.MethodGet, "/docs", "")
+	assertStatus(t, rec, http.StatusOK)
+
+	if !regexp.MustCompile(`swagger-ui-dist@\d+\.\d+\.\d+/`).MatchString(swaggerUIBase) {
+		t.Errorf("swaggerUIBase = %q, want an exact version", swaggerUIBase)
+	}
+	if strings.Count(rec.Body.String(), "https://") != strings.Count(rec.Body.String(), swaggerUIBase) {
+		t.Errorf("docs page loads assets outside %s", swaggerUIBase)
+	}
+
+	csp := rec.Header().Get("Content-Security-Policy")
+	if !strings.Contains(csp, "script-src "+swaggerUIBase+" 'sha256-") {
+		t.Errorf("Content-Security-Policy = %q, want scripts limited to %s", csp, swaggerUIBase)
+	}
+}
+
+func TestOpenAPIRoute(t *testing.T) {
+	h := newTestApplication(t).mount()
+
+	rec := doRequest(t, h, http.MethodGet, "/openapi.json", "")
+	assertStatus(t, rec, http.StatusOK)
+
+	var spec map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &spec); err != nil {
+		t.Fatalf("/openapi.json is not JSON: %v", err)
+	}
+}
+
+func TestUnknownRoutes(t *testing.T) {
+	h := newTestApplication(t).mount()
+
+	rec := doRequest(t, h, http.MethodGet, "/tidak-ada", "")
+	assertError(t, rec, http.StatusNotFound, codeNotFound)
+
+	rec = doRequest(t, h, http.MethodPut, "/questions", "")
+	assertError(t, rec, http.StatusMethodNotAllowed, codeMethodNotAllowed)
+}
+
+func TestLocale(t *testing.T) {
+	h := newTestApplication(t).mount()
+
+	tests := []struct {
+		acceptLanguage string
+		locale         string
+		message        string
+	}{
+		{"", "id", "data tidak ditemukan"},
+		{"en-US,en;q=0.9", "en", "not found"},
+		{"fr, en;q=0.5", "en", "not found"},
+		{"fr", "id", "data tidak ditemukan"},
+		{"fr, en;q=0", "id", "data tidak ditemukan"},
+		{"en;q=0", "id", "data tidak ditemukan"},
+		{"en;q=1.5, id;q=0.5", "id", "data tidak ditemukan"},
+		{"id;q=-1, en;q=0.2", "en", "not found"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.acceptLanguage, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "/questions/99/comments", nil)
+			req.Header.Set("Accept-Language", tt.acceptLanguage)
+			rec := doRequestWith(t, h, req)
+
+			res := assertError(t, rec, http.StatusNotFound, codeNotFound)
+			if res.Error.Message != tt.message {
+				t.Errorf("message = %q, want %q", res.Error.Message, tt.message)
+			}
+			if got := rec.Header().Get("Content-Language"); got != tt.locale {
+				t.Errorf("Content-Language = %q, want %q", got, tt.locale)
+			}
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ReyviRahman/to-backend/internal/models"
+	"github.com/ReyviRahman/to-backend/internal/richtext"
+	"github.com/ReyviRahman/to-backend/internal/store"
+)
+
+type CreateCommentPayload struct {
+	// Isi komentar dalam format markdown + LaTeX, divalidasi seperti konten soal
+	Body string `json:"body" validate:"required,max=5000"`
+
+	// Opsional, diisi jika komentar adalah balasan
+	ParentID *int64 `json:"parent_id" validate:"omitempty,gt=0"`
+}
+
+type ModerateCommentPayload struct {
+	// Pointer supaya bisa membedakan "tidak dikirim" dengan "false"
+	IsOfficial *bool `json:"is_official"`
+	IsHidden   *bool `json:"is_hidden"`
+}
+
+func (app *application) createCommentHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := readIDParam(r)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	var payload CreateCommentPayload
+	if err := readJSON(w, r, &payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(payload); err != nil {
+		app.validationErrorResponse(w, r, err)
+		return
+	}
+
+	// Komentar memakai subset markdown + LaTeX yang sama dengan konten soal
+	bodyHTML, err := richtext.Render(payload.Body)
+	if err != nil {
+		app.fieldErrorResponse(w, r, map[string]error{"body": err})
+		return
+	}
+
+	ctx := r.Context()
+	question, err := app.store.Questions.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			app.notFoundResponse(w, r, err)
+			return
+		}
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	// Diskusi hanya dibuka untuk soal yang sudah tayang
+	if question.Status != models.QuestionStatusPublished {
+		app.conflictResponse(w, r, newLocalizedError("error.question_not_published"))
+		return
+	}
+
+	comment := &models.Comment{
+		QuestionID: id,
+		Body:       payload.Body,
+		BodyHTML:   bodyHTML,
+	}
+
+	if payload.ParentID != nil {
+		parent, err := app.store.Comments.GetByID(ctx, *payload.ParentID)
+		if err != nil {
+			if errors.Is(err, store.ErrNotFound) {
+				app.badRequestResponse(w, r, newLocalizedError("error.parent_not_found"))
+				return
+			}
+			app.internalServerError(w, r, err)
+			return
+		}
+
+		if parent.QuestionID != id {
+			app.badRequestResponse(w, r, newLocalizedError("error.parent_wrong_question"))
+			return
+		}
+
+		// Thread hanya satu tingkat: balasan dari balasan ditempelkan ke komentar utamanya
+		comment.ParentID = &parent.ID
+		if parent.ParentID != nil {
+			comment.ParentID = parent.ParentID
+		}
+	}
+
+	if err := app.store.Comments.Create(ctx, comment); err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+	commentsPostedTotal.Inc()
+
+	if err := app.jsonResponse(w, r, http.StatusCreated, "comment.created", comment); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+func (app *application) getCommentsHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := readIDParam(r)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	cq := store.PaginatedCommentQuery{
+		Limit:  20,
+		Offset: 0,
+	}
+
+	cq, err = cq.Parse(r)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(cq); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	ctx := r.Context()
+	if _, err := app.store.Questions.GetByID(ctx, id); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			app.notFoundResponse(w, r, err)
+			return
+		}
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	comments, meta, err := app.store.Comments.GetByQuestionID(ctx, id, cq)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := app.jsonResponse(w, r, http.StatusOK, "success.fetched", comments, meta); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+func (app *application) moderateCommentHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := readIDParam(r)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	var payload ModerateCommentPayload
+	if err := readJSON(w, r, &payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	ctx := r.Context()
+	comment, err := app.store.Comments.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			app.notFoundResponse(w, r, err)
+			return
+		}
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if payload.IsOfficial != nil {
+		// Jawaban resmi adalah balasan editor, bukan komentar utama
+		if *payload.IsOfficial && comment.ParentID == nil {
+			app.badRequestResponse(w, r, newLocalizedError("error.official_reply_only"))
+			return
+		}
+		comment.IsOfficial = *payload.IsOfficial
+	}
+
+	if payload.IsHidden != nil {
+		comment.IsHidden = *payload.IsHidden
+	}
+
+	if err := app.store.Comments.Update(ctx, comment); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			app.notFoundResponse(w, r, err)
+			return
+		}
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := app.jsonResponse(w, r, http.StatusOK, "comment.updated", comment); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+func (app *application) deleteCommentHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := readIDParam(r)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	ctx := r.Context()
+	if err := app.store.Comments.Delete(ctx, id); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			app.notFoundResponse(w, r, err)
+			return
+		}
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := app.jsonResponse(w, r, http.StatusOK, "comment.deleted", nil); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
@@ -0,0 +1,199 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ReyviRahman/to-backend/internal/models"
+)
+
+// postComment membuat komentar lewat API dan mengembalikan hasilnya
+func postComment(t *testing.T, h http.Handler, questionID int64, body string) models.Comment {
+	t.Helper()
+
+	rec := doRequest(t, h, http.MethodPost, fmt.Sprintf("/questions/%d/comments", questionID), body)
+	assertStatus(t, rec, http.StatusCreated)
+
+	var c models.Comment
+	decodeData(t, decodeResponse(t, rec), &c)
+	return c
+}
+
+func TestCommentThreads(t *testing.T) {
+	app := newTestApplication(t)
+	q := seedQuestion(t, app, "TIU", "Soal yang didiskusikan", models.QuestionStatusPublished)
+	h := app.mount()
+
+	first := postComment(t, h, q.ID, `{"body":"Kenapa jawabannya A?"}`)
+	second := postComment(t, h, q.ID, `{"body":"Soalnya **sulit**"}`)
+	if second.BodyHTML != "<p>Soalnya <strong>sulit</strong></p>" {
+		t.Errorf("body_html = %q, want rendered markdown", second.BodyHTML)
+	}
+	reply := postComment(t, h, q.ID, fmt.Sprintf(`{"body":"Karena penyebutnya sama","parent_id":%d}`, first.ID))
+
+	// Balasan dari balasan ditempelkan ke komentar utama
+	nested := postComment(t, h, q.ID, fmt.Sprintf(`{"body":"Terima kasih","parent_id":%d}`, reply.ID))
+	if nested.ParentID == nil || *nested.ParentID != first.ID {
+		t.Fatalf("nested reply parent = %v, want %d", nested.ParentID, first.ID)
+	}
+
+	rec := doRequest(t, h, http.MethodPatch, fmt.Sprintf("/comments/%d", nested.ID), `{"is_official":true}`)
+	assertStatus(t, rec, http.StatusOK)
+
+	rec = doRequest(t, h, http.MethodPatch, fmt.Sprintf("/comments/%d", second.ID), `{"is_hidden":true}`)
+	assertStatus(t, rec, http.StatusOK)
+
+	rec = doRequest(t, h, http.MethodGet, fmt.Sprintf("/questions/%d/comments", q.ID), "")
+	assertStatus(t, rec, http.StatusOK)
+
+	res := decodeResponse(t, rec)
+	var threads []models.Comment
+	decodeData(t, res, &threads)
+
+	if len(threads) != 1 || threads[0].ID != first.ID {
+		t.Fatalf("threads = %+v, want only comment %d (the other is hidden)", threads, first.ID)
+	}
+	if res.Meta.TotalItems != 1 {
+		t.Errorf("total_items = %d, want 1", res.Meta.TotalItems)
+	}
+
+	replies := threads[0].Replies
+	if len(replies) != 2 || replies[0].ID != nested.ID || !replies[0].IsOfficial {
+		t.Errorf("replies = %+v, want official reply %d first", replies, nested.ID)
+	}
+}
+
+func TestGetCommentsPagination(t *testing.T) {
+	app := newTestApplication(t)
+	q := seedQuestion(t, app, "TIU", "Soal yang ramai dibahas", models.QuestionStatusPublished)
+	h := app.mount()
+
+	for i := 1; i <= 5; i++ {
+		postComment(t, h, q.ID, fmt.Sprintf(`{"body":"komentar %d"}`, i))
+	}
+
+	rec := doRequest(t, h, http.MethodGet, fmt.Sprintf("/questions/%d/comments?limit=2&offset=4", q.ID), "")
+	assertStatus(t, rec, http.StatusOK)
+
+	res := decodeResponse(t, rec)
+	var threads []models.Comment
+	decodeData(t, res, &threads)
+
+	if len(threads) != 1 || threads[0].Body != "komentar 5" {
+		t.Errorf("threads = %+v, want only komentar 5", threads)
+	}
+	if res.Meta.CurrentPage != 3 || res.Meta.TotalPages != 3 {
+		t.Errorf("meta = %+v, want page 3 of 3", res.Meta)
+	}
+
+	for _, query := range []string{"?limit=21", "?limit=x", "?limit=2&offset=x"} {
+		rec = doRequest(t, h, http.MethodGet, fmt.Sprintf("/questions/%d/comments%s", q.ID, query), "")
+		assertError(t, rec, http.StatusBadRequest, codeInvalidQuery)
+	}
+}
+
+func TestCreateCommentErrors(t *testing.T) {
+	app := newTestApplication(t)
+	q := seedQuestion(t, app, "TIU", "Soal pertama", models.QuestionStatusPublished)
+	other := seedQuestion(t, app, "TIU", "Soal kedua", models.QuestionStatusPublished)
+	retired := seedQuestion(t, app, "TIU", "Soal yang sudah ditarik", models.QuestionStatusRetired)
+	h := app.mount()
+	otherComment := postComment(t, h, other.ID, `{"body":"komentar di soal lain"}`)
+	target := fmt.Sprintf("/questions/%d/comments", q.ID)
+
+	tests := []struct {
+		name   string
+		target string
+		body   string
+		status int
+		code   string
+	}{
+		{"empty body", target, `{"body":""}`, http.StatusUnprocessableEntity, codeValidationFailed},
+		{"unknown parent", target, `{"body":"balas","parent_id":99}`, http.StatusBadRequest, "parent_not_found"},
+		{"parent on other question", target, fmt.Sprintf(`{"body":"balas","parent_id":%d}`, otherComment.ID), http.StatusBadRequest, "parent_wrong_question"},
+		{"unknown question", "/questions/99/comments", `{"body":"halo"}`, http.StatusNotFound, codeNotFound},
+		{"unpublished question", fmt.Sprintf("/questions/%d/comments", retired.ID), `{"body":"halo"}`, http.StatusConflict, "question_not_published"},
+		{"two JSON values", target, `{"body":"a"}{"body":"b"}`, http.StatusBadRequest, "single_json_value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := doRequest(t, h, http.MethodPost, tt.target, tt.body)
+			assertError(t, rec, tt.status, tt.code)
+		})
+	}
+
+	rec := doRequest(t, h, http.MethodGet, "/questions/99/comments", "")
+	assertError(t, rec, http.StatusNotFound, codeNotFound)
+}
+
+// Isi komentar divalidasi dengan aturan markup yang sama seperti konten soal
+func TestCreateCommentMarkup(t *testing.T) {
+	app := newTestApplication(t)
+	q := seedQuestion(t, app, "TIU", "Soal dengan komentar", models.QuestionStatusPublished)
+	h := app.mount()
+	target := fmt.Sprintf("/questions/%d/comments", q.ID)
+
+	tests := []struct {
+		name string
+		body string
+		code string
+	}{
+		{"html", `{"body":"<script>alert(1)</script>"}`, "html"},
+		{"heading", `{"body":"# Judul"}`, "heading"},
+		{"link", `{"body":"[klik](https://contoh.com)"}`, "link"},
+		{"unsupported tex", `{"body":"$\\foo$"}`, "tex_unsupported_command"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := doRequest(t, h, http.MethodPost, target, tt.body)
+			res := assertError(t, rec, http.StatusUnprocessableEntity, codeValidationFailed)
+
+			if got := res.Error.Fields["body"]; got.Code != tt.code || got.Message == "" {
+				t.Errorf("body field error = %+v, want code %q", got, tt.code)
+			}
+		})
+	}
+
+	c := postComment(t, h, q.ID, `{"body":"Jawabannya $\\frac{1}{2}$"}`)
+	if !strings.Contains(c.BodyHTML, `class="math math-inline"`) {
+		t.Errorf("body_html = %q, want rendered math", c.BodyHTML)
+	}
+}
+
+func TestModerateComment(t *testing.T) {
+	app := newTestApplication(t)
+	q := seedQuestion(t, app, "TIU", "Soal dengan komentar", models.QuestionStatusPublished)
+	h := app.mount()
+	top := postComment(t, h, q.ID, `{"body":"komentar utama"}`)
+
+	rec := doRequest(t, h, http.MethodPatch, fmt.Sprintf("/comments/%d", top.ID), `{"is_official":true}`)
+	assertError(t, rec, http.StatusBadRequest, "official_reply_only")
+
+	rec = doRequest(t, h, http.MethodPatch, "/comments/99", `{"is_hidden":true}`)
+	assertError(t, rec, http.StatusNotFound, codeNotFound)
+
+	rec = doRequest(t, h, http.MethodPatch, fmt.Sprintf("/comments/%d", top.ID), `{"is_hidden":"yes"}`)
+	assertError(t, rec, http.StatusBadRequest, codeInvalidJSON)
+}
+
+func TestDeleteComment(t *testing.T) {
+	app := newTestApplication(t)
+	q := seedQuestion(t, app, "TIU", "Soal dengan komentar", models.QuestionStatusPublished)
+	h := app.mount()
+	top := postComment(t, h, q.ID, `{"body":"komentar utama"}`)
+	reply := postComment(t, h, q.ID, fmt.Sprintf(`{"body":"balasan","parent_id":%d}`, top.ID))
+
+	rec := doRequest(t, h, http.MethodDelete, fmt.Sprintf("/comments/%d", top.ID), "")
+	assertStatus(t, rec, http.StatusOK)
+
+	// Balasan ikut terhapus bersama komentar utamanya
+	rec = doRequest(t, h, http.MethodDelete, fmt.Sprintf("/comments/%d", reply.ID), "")
+	assertError(t, rec, http.StatusNotFound, codeNotFound)
+
+	rec = doRequest(t, h, http.MethodDelete, "/comments/abc", "")
+	assertError(t, rec, http.StatusBadRequest, "invalid_id")
+}
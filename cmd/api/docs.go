@@ -0,0 +1,61 @@
+package main
+
+import (
+	"crypto/sha256"
+	_ "embed"
+	"encoding/base64"
+	"net/http"
+)
+
+// openAPISpec dipelihara manual. Test di openapi_test.go gagal jika route
+// yang terdaftar di mount() dan path di dokumen ini tidak sama.
+//
+//go:embed openapi.json
+var openAPISpec []byte
+
+// swaggerUIBase dipin ke versi persis, bukan tag major yang bisa berubah isi
+// tanpa sepengetahuan kita. Naikkan versinya secara sadar, sekaligus docsCSP.
+const swaggerUIBase = "https://unpkg.com/swagger-ui-dist@5.17.14/"
+
+// docsScript adalah satu-satunya script inline di halaman docs.
+// Hash-nya dimasukkan ke docsCSP sehingga script inline lain tidak jalan.
+const docsScript = `window.ui = SwaggerUIBundle({ url: "/openapi.json", dom_id: "#swagger-ui" });`
+
+// docsPage memuat Swagger UI dari CDN dan membaca spesifikasi dari /openapi.json
+var docsPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="utf-8">
+	<meta name="viewport" content="width=device-width, initial-scale=1">
+	<title>Tryout Backend API</title>
+	<link rel="stylesheet" href="` + swaggerUIBase + `swagger-ui.css" crossorigin="anonymous">
+</head>
+<body>
+	<div id="swagger-ui"></div>
+	<script src="` + swaggerUIBase + `swagger-ui-bundle.js" crossorigin="anonymous"></script>
+	<script>` + docsScript + `</script>
+</body>
+</html>
+`
+
+// docsCSP membatasi browser hanya memuat asset dari path versi yang dipin,
+// jadi file di versi lain di CDN yang sama tetap ditolak
+var docsCSP = func() string {
+	sum := sha256.Sum256([]byte(docsScript))
+	return "default-src 'none'; " +
+		"script-src " + swaggerUIBase + " 'sha256-" + base64.StdEncoding.EncodeToString(sum[:]) + "'; " +
+		"style-src " + swaggerUIBase + " 'unsafe-inline'; " +
+		"img-src 'self' data:; " +
+		"connect-src 'self'"
+}()
+
+func (app *application) openAPIHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(openAPISpec)
+}
+
+func (app *application) docsHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Content-Security-Policy", docsCSP)
+	w.Write([]byte(docsPage))
+}
@@ -1,68 +1,240 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
+	"strings"
+
+	"github.com/ReyviRahman/to-backend/internal/richtext"
+	"github.com/ReyviRahman/to-backend/internal/store"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-playground/validator/v10"
+)
+
+// Kode error yang dikirim ke client. Nilainya adalah kontrak API:
+// client bercabang berdasarkan kode ini, jadi jangan diubah.
+// Error dari newLocalizedError memakai key katalog tanpa prefix "error."
+// sebagai kodenya (misal "error.invalid_id" menjadi "invalid_id").
+const (
+	codeInternal          = "internal_error"
+	codeForbidden         = "forbidden"
+	codeUnauthorized      = "unauthorized"
+	codeNotFound          = "not_found"
+	codeMethodNotAllowed  = "method_not_allowed"
+	codeConflict          = "conflict"
+	codeRateLimitExceeded = "rate_limit_exceeded"
+	codeValidationFailed  = "validation_failed"
+	codeBadRequest        = "bad_request"
+	codeInvalidJSON       = "invalid_json"
+	codeBodyTooLarge      = "body_too_large"
+	codeInvalidQuery      = "invalid_query"
 )
 
+// apiError adalah bentuk semua response error: {"error": apiError}
+type apiError struct {
+	Code      string                `json:"code"`
+	Message   string                `json:"message"`
+	RequestID string                `json:"request_id,omitempty"`
+	Fields    map[string]fieldError `json:"fields,omitempty"`
+}
+
+type fieldError struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// errorResponse menambahkan request ID lalu menulis apiError ke response
+func (app *application) errorResponse(w http.ResponseWriter, r *http.Request, status int, apiErr apiError) {
+	apiErr.RequestID = middleware.GetReqID(r.Context())
+
+	writeJSONError(w, status, apiErr)
+}
+
 func (app *application) internalServerError(w http.ResponseWriter, r *http.Request, err error) {
 	// Log yang sangat detail untuk developer
-	app.logger.Errorw("internal error", "method", r.Method, "path", r.URL.Path, "error", err.Error())
+	fields := []any{"error", err.Error()}
+	var pe *panicError
+	if errors.As(err, &pe) {
+		fields = append(fields, "stack", string(pe.stack))
+	}
+	app.requestLogger(r).Errorw("internal error", fields...)
 
 	// Pesan yang sangat aman untuk user
-	writeJSONError(w, http.StatusInternalServerError, "the server encountered a problem")
+	app.errorResponse(w, r, http.StatusInternalServerError, apiError{
+		Code:    codeInternal,
+		Message: app.t(r, "error.internal"),
+	})
 }
 
 func (app *application) forbiddenResponse(w http.ResponseWriter, r *http.Request) {
-	app.logger.Warnw("forbidden", "method", r.Method, "path", r.URL.Path, "error")
+	app.requestLogger(r).Warnw("forbidden")
 
-	writeJSONError(w, http.StatusForbidden, "forbidden")
+	app.errorResponse(w, r, http.StatusForbidden, apiError{
+		Code:    codeForbidden,
+		Message: app.t(r, "error.forbidden"),
+	})
 }
 
+// badRequestResponse memilih kode berdasarkan jenis error. Error validasi
+// query parameter ikut menyertakan detail per parameter di fields.
 func (app *application) badRequestResponse(w http.ResponseWriter, r *http.Request, err error) {
-	app.logger.Warnw("bad request", "method", r.Method, "path", r.URL.Path, "error", err.Error())
-
-	writeJSONError(w, http.StatusBadRequest, err.Error())
+	app.requestLogger(r).Warnw("bad request", "error", err.Error())
+
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		app.errorResponse(w, r, http.StatusBadRequest, apiError{
+			Code:    codeInvalidQuery,
+			Message: app.t(r, "error.invalid_query"),
+			Fields:  app.parseValidationError(r, validationErrors),
+		})
+		return
+	}
+
+	var paramErr *store.QueryParamError
+	if errors.As(err, &paramErr) {
+		app.errorResponse(w, r, http.StatusBadRequest, apiError{
+			Code:    codeInvalidQuery,
+			Message: app.t(r, "error.invalid_query"),
+			Fields: map[string]fieldError{
+				paramErr.Param: {Code: "integer", Message: app.t(r, "validation.integer")},
+			},
+		})
+		return
+	}
+
+	app.errorResponse(w, r, http.StatusBadRequest, apiError{
+		Code:    errorCode(err, codeBadRequest),
+		Message: app.translateError(r, err),
+	})
 }
 
 func (app *application) conflictResponse(w http.ResponseWriter, r *http.Request, err error) {
-	app.logger.Errorf("conflict response", "method", r.Method, "path", r.URL.Path, "error", err.Error())
+	app.requestLogger(r).Warnw("conflict response", "error", err.Error())
 
-	writeJSONError(w, http.StatusConflict, err.Error())
+	app.errorResponse(w, r, http.StatusConflict, apiError{
+		Code:    errorCode(err, codeConflict),
+		Message: app.translateError(r, err),
+	})
 }
 
 func (app *application) notFoundResponse(w http.ResponseWriter, r *http.Request, err error) {
-	app.logger.Warnf("not found error", "method", r.Method, "path", r.URL.Path, "error", err.Error())
+	app.requestLogger(r).Warnw("not found error", "error", err.Error())
 
-	writeJSONError(w, http.StatusNotFound, "not found")
+	app.errorResponse(w, r, http.StatusNotFound, apiError{
+		Code:    codeNotFound,
+		Message: app.t(r, "error.not_found"),
+	})
+}
+
+// routeNotFoundResponse dan methodNotAllowedResponse menggantikan response
+// plain text bawaan chi supaya route yang salah juga memakai format error yang sama
+func (app *application) routeNotFoundResponse(w http.ResponseWriter, r *http.Request) {
+	app.errorResponse(w, r, http.StatusNotFound, apiError{
+		Code:    codeNotFound,
+		Message: app.t(r, "error.not_found"),
+	})
+}
+
+func (app *application) methodNotAllowedResponse(w http.ResponseWriter, r *http.Request) {
+	app.errorResponse(w, r, http.StatusMethodNotAllowed, apiError{
+		Code:    codeMethodNotAllowed,
+		Message: app.t(r, "error.method_not_allowed", r.Method),
+	})
 }
 
 func (app *application) unauthorizedErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
-	app.logger.Warnf("unauthorized error", "method", r.Method, "path", r.URL.Path, "error", err.Error())
+	app.requestLogger(r).Warnw("unauthorized error", "error", err.Error())
 
-	writeJSONError(w, http.StatusUnauthorized, "unauthorized")
+	app.errorResponse(w, r, http.StatusUnauthorized, apiError{
+		Code:    codeUnauthorized,
+		Message: app.t(r, "error.unauthorized"),
+	})
 }
 
 func (app *application) unauthorizedBasicErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
-	app.logger.Warnf("unauthorized basic error", "method", r.Method, "path", r.URL.Path, "error", err.Error())
+	app.requestLogger(r).Warnw("unauthorized basic error", "error", err.Error())
 
 	w.Header().Set("WWW-Authenticate", `Basic realm="restricted", charset="UTF-8"`)
 
-	writeJSONError(w, http.StatusUnauthorized, "unauthorized")
+	app.errorResponse(w, r, http.StatusUnauthorized, apiError{
+		Code:    codeUnauthorized,
+		Message: app.t(r, "error.unauthorized"),
+	})
 }
 
 func (app *application) rateLimitExceededResponse(w http.ResponseWriter, r *http.Request, retryAfter string) {
-	app.logger.Warnw("rate limit exceeded", "method", r.Method, "path", r.URL.Path)
+	app.requestLogger(r).Warnw("rate limit exceeded")
 
 	w.Header().Set("Retry-After", retryAfter)
 
-	writeJSONError(w, http.StatusTooManyRequests, "rate limit exceeded, retry after: "+retryAfter)
+	app.errorResponse(w, r, http.StatusTooManyRequests, apiError{
+		Code:    codeRateLimitExceeded,
+		Message: app.t(r, "error.rate_limit", retryAfter),
+	})
 }
 
 func (app *application) validationErrorResponse(w http.ResponseWriter, r *http.Request, err error) {
-	app.logger.Warnw("validation error", "method", r.Method, "path", r.URL.Path, "error", err.Error())
+	app.requestLogger(r).Warnw("validation error", "error", err.Error())
+
+	app.errorResponse(w, r, http.StatusUnprocessableEntity, apiError{
+		Code:    codeValidationFailed,
+		Message: app.t(r, "error.validation"),
+		Fields:  app.parseValidationError(r, err),
+	})
+}
 
-	errors := app.parseValidationError(err)
+// fieldErrorResponse dipakai untuk validasi di luar validator (misal markup konten soal),
+// dengan bentuk response yang sama seperti validationErrorResponse
+func (app *application) fieldErrorResponse(w http.ResponseWriter, r *http.Request, fieldErrors map[string]error) {
+	fields := make(map[string]fieldError, len(fieldErrors))
+	for field, err := range fieldErrors {
+		fields[field] = fieldError{
+			Code:    errorCode(err, codeValidationFailed),
+			Message: app.translateError(r, err),
+		}
+	}
+
+	app.requestLogger(r).Warnw("validation error", "fields", fields)
+
+	app.errorResponse(w, r, http.StatusUnprocessableEntity, apiError{
+		Code:    codeValidationFailed,
+		Message: app.t(r, "error.validation"),
+		Fields:  fields,
+	})
+}
 
-	// Kita kirim map-nya langsung ke user
-	writeJSON(w, http.StatusUnprocessableEntity, map[string]any{"error": errors})
+// errorCode mengambil kode stabil dari error yang dikenal,
+// atau fallback jika error tidak punya kode sendiri
+func errorCode(err error, fallback string) string {
+	var le *localizedError
+	if errors.As(err, &le) {
+		return strings.TrimPrefix(le.key, "error.")
+	}
+
+	var re *richtext.Error
+	if errors.As(err, &re) {
+		return re.Code
+	}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var maxBytesErr *http.MaxBytesError
+
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		return codeNotFound
+	case errors.Is(err, store.ErrConflict):
+		return codeConflict
+	case errors.As(err, &maxBytesErr):
+		return codeBodyTooLarge
+	case errors.As(err, &syntaxErr), errors.As(err, &typeErr),
+		errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF),
+		strings.HasPrefix(err.Error(), "json: unknown field"):
+		// Decoder JSON tidak punya tipe error untuk unknown field
+		return codeInvalidJSON
+	}
+
+	return fallback
 }
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"time"
+
+	"github.com/ReyviRahman/to-backend/internal/db"
+)
+
+// checkResult sengaja tidak membawa pesan error: /readyz bisa diakses tanpa
+// autentikasi, jadi detail error hanya dicatat di log
+type checkResult struct {
+	Status string `json:"status"`
+}
+
+type migrationCheckResult struct {
+	checkResult
+	Version         int  `json:"version"`
+	ExpectedVersion int  `json:"expected_version"`
+	Dirty           bool `json:"dirty"`
+}
+
+// poolStats adalah sql.DBStats dengan nama field JSON yang konsisten
+type poolStats struct {
+	MaxOpenConnections int   `json:"max_open_connections"`
+	OpenConnections    int   `json:"open_connections"`
+	InUse              int   `json:"in_use"`
+	Idle               int   `json:"idle"`
+	WaitCount          int64 `json:"wait_count"`
+	WaitDurationMs     int64 `json:"wait_duration_ms"`
+	MaxIdleClosed      int64 `json:"max_idle_closed"`
+	MaxIdleTimeClosed  int64 `json:"max_idle_time_closed"`
+	MaxLifetimeClosed  int64 `json:"max_lifetime_closed"`
+}
+
+type readinessReport struct {
+	Status     string               `json:"status"`
+	Database   checkResult          `json:"database"`
+	Migrations migrationCheckResult `json:"migrations"`
+	Pool       poolStats            `json:"pool"`
+}
+
+// healthzHandler hanya memastikan proses masih hidup, tanpa cek dependency
+func (app *application) healthzHandler(w http.ResponseWriter, r *http.Request) {
+	if err := app.jsonResponse(w, r, http.StatusOK, "health.ok", map[string]string{"status": "ok"}); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+// readyzHandler memastikan API siap menerima traffic: database bisa di-ping,
+// versi migrasi sesuai dengan binary, dan server tidak sedang shutdown
+func (app *application) readyzHandler(w http.ResponseWriter, r *http.Request) {
+	if app.shuttingDown.Load() {
+		report := map[string]string{"status": "shutting_down"}
+		if err := app.jsonResponse(w, r, http.StatusServiceUnavailable, "health.unavailable", report); err != nil {
+			app.internalServerError(w, r, err)
+		}
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), 2*time.Second)
+	defer cancel()
+
+	report := readinessReport{
+		Status:     "ok",
+		Database:   checkResult{Status: "ok"},
+		Migrations: migrationCheckResult{checkResult: checkResult{Status: "ok"}, ExpectedVersion: db.SchemaVersion},
+		Pool:       newPoolStats(app.db.Stats()),
+	}
+
+	logger := app.requestLogger(r)
+
+	if err := app.db.PingContext(ctx); err != nil {
+		report.Database.Status = "error"
+		logger.Warnw("readiness check failed", "check", "database", "error", err.Error())
+	}
+
+	version, dirty, err := db.MigrationVersion(ctx, app.db)
+	report.Migrations.Version = version
+	report.Migrations.Dirty = dirty
+	switch {
+	case err != nil:
+		report.Migrations.Status = "error"
+		logger.Warnw("readiness check failed", "check", "migrations", "error", err.Error())
+	case dirty || version != db.SchemaVersion:
+		report.Migrations.Status = "error"
+		logger.Warnw("readiness check failed", "check", "migrations", "error", "schema version mismatch",
+			"version", version, "expected_version", db.SchemaVersion, "dirty", dirty)
+	}
+
+	status, message := http.StatusOK, "health.ok"
+	if report.Database.Status != "ok" || report.Migrations.Status != "ok" {
+		report.Status = "unavailable"
+		status, message = http.StatusServiceUnavailable, "health.unavailable"
+	}
+
+	if err := app.jsonResponse(w, r, status, message, report); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+func newPoolStats(s sql.DBStats) poolStats {
+	return poolStats{
+		MaxOpenConnections: s.MaxOpenConnections,
+		OpenConnections:    s.OpenConnections,
+		InUse:              s.InUse,
+		Idle:               s.Idle,
+		WaitCount:          s.WaitCount,
+		WaitDurationMs:     s.WaitDuration.Milliseconds(),
+		MaxIdleClosed:      s.MaxIdleClosed,
+		MaxIdleTimeClosed:  s.MaxIdleTimeClosed,
+		MaxLifetimeClosed:  s.MaxLifetimeClosed,
+	}
+}
@@ -0,0 +1,102 @@
+//go:build integration
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/ReyviRahman/to-backend/internal/db"
+	"github.com/ReyviRahman/to-backend/internal/dbtest"
+	"github.com/ReyviRahman/to-backend/internal/store"
+)
+
+var pg *dbtest.Postgres
+
+func TestMain(m *testing.M) {
+	var err error
+	pg, err = dbtest.Start()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	code := m.Run()
+
+	pg.Stop()
+	os.Exit(code)
+}
+
+func newPostgresApplication(t *testing.T) *application {
+	t.Helper()
+
+	if err := pg.Reset(); err != nil {
+		t.Fatal(err)
+	}
+
+	app := newTestApplication(t)
+	app.db = pg.DB
+	app.store = store.NewStorage(pg.DB)
+	return app
+}
+
+func TestReadyzWithDatabase(t *testing.T) {
+	h := newPostgresApplication(t).mount()
+
+	rec := doRequest(t, h, http.MethodGet, "/readyz", "")
+	assertStatus(t, rec, http.StatusOK)
+
+	var report readinessReport
+	decodeData(t, decodeResponse(t, rec), &report)
+	if report.Database.Status != "ok" || report.Migrations.Version != db.SchemaVersion {
+		t.Errorf("report = %+v, want database ok at schema version %d", report, db.SchemaVersion)
+	}
+}
+
+func TestReadyzSchemaMismatch(t *testing.T) {
+	h := newPostgresApplication(t).mount()
+
+	if _, err := pg.DB.Exec(`UPDATE schema_migrations SET version = version - 1`); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		pg.DB.Exec(`UPDATE schema_migrations SET version = version + 1`)
+	})
+
+	rec := doRequest(t, h, http.MethodGet, "/readyz", "")
+	assertStatus(t, rec, http.StatusServiceUnavailable)
+
+	var report readinessReport
+	decodeData(t, decodeResponse(t, rec), &report)
+	if report.Migrations.Status != "error" || report.Migrations.ExpectedVersion != db.SchemaVersion {
+		t.Errorf("migrations = %+v, want a version mismatch error", report.Migrations)
+	}
+}
+
+// TestQuestionsWithDatabase menjalankan alur utama soal lewat HTTP
+// dengan store Postgres, termasuk kolom JSONB options dan kolom HTML
+func TestQuestionsWithDatabase(t *testing.T) {
+	h := newPostgresApplication(t).mount()
+
+	rec := doRequest(t, h, http.MethodPost, "/questions", validQuestionJSON)
+	assertStatus(t, rec, http.StatusCreated)
+
+	rec = doRequest(t, h, http.MethodPatch, "/questions/1/status", `{"status":"in_review"}`)
+	assertStatus(t, rec, http.StatusOK)
+
+	rec = doRequest(t, h, http.MethodGet, "/questions?status=in_review&search=hasil", "")
+	assertStatus(t, rec, http.StatusOK)
+
+	res := decodeResponse(t, rec)
+	if res.Meta == nil || res.Meta.TotalItems != 1 {
+		t.Fatalf("meta = %+v, want one question", res.Meta)
+	}
+
+	rec = doRequest(t, h, http.MethodDelete, "/questions/1", "")
+	assertStatus(t, rec, http.StatusOK)
+
+	rec = doRequest(t, h, http.MethodGet, "/questions/1/comments", "")
+	assertError(t, rec, http.StatusNotFound, codeNotFound)
+}
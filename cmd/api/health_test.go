@@ -0,0 +1,71 @@
+package main
+
+import (
+	"database/sql"
+	"net/http"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestHealthz(t *testing.T) {
+	h := newTestApplication(t).mount()
+
+	rec := doRequest(t, h, http.MethodGet, "/healthz", "")
+	assertStatus(t, rec, http.StatusOK)
+
+	var data map[string]string
+	decodeData(t, decodeResponse(t, rec), &data)
+	if data["status"] != "ok" {
+		t.Errorf("status = %q, want ok", data["status"])
+	}
+}
+
+// Pemeriksaan database di /readyz ada di health_integration_test.go
+func TestReadyzDuringShutdown(t *testing.T) {
+	app := newTestApplication(t)
+	app.shuttingDown.Store(true)
+	h := app.mount()
+
+	rec := doRequest(t, h, http.MethodGet, "/readyz", "")
+	assertStatus(t, rec, http.StatusServiceUnavailable)
+
+	var data map[string]string
+	decodeData(t, decodeResponse(t, rec), &data)
+	if data["status"] != "shutting_down" {
+		t.Errorf("status = %q, want shutting_down", data["status"])
+	}
+}
+
+// Detail error database hanya masuk log, bukan ke response /readyz
+func TestReadyzHidesErrors(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	app := newTestApplication(t)
+	app.logger = zap.New(core).Sugar()
+
+	conn, err := sql.Open("postgres", "postgres://rahasia@localhost/tryout")
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.Close()
+	app.db = conn
+	h := app.mount()
+
+	rec := doRequest(t, h, http.MethodGet, "/readyz", "")
+	assertStatus(t, rec, http.StatusServiceUnavailable)
+
+	var report readinessReport
+	decodeData(t, decodeResponse(t, rec), &report)
+	if report.Status != "unavailable" || report.Database.Status != "error" || report.Migrations.Status != "error" {
+		t.Errorf("report = %+v, want database and migrations errors", report)
+	}
+	if strings.Contains(rec.Body.String(), "database is closed") || strings.Contains(rec.Body.String(), `"error":`) {
+		t.Errorf("response leaks error details: %s", rec.Body.String())
+	}
+
+	if logs.FilterMessage("readiness check failed").Len() != 2 {
+		t.Errorf("got %d readiness log entries, want one per failed check", logs.FilterMessage("readiness check failed").Len())
+	}
+}
@@ -0,0 +1,312 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ReyviRahman/to-backend/internal/richtext"
+	"github.com/ReyviRahman/to-backend/internal/store"
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/id"
+	ut "github.com/go-playground/universal-translator"
+)
+
+// Katalog pesan per bahasa. Parameter ditulis {0}, {1}, dst.
+// Bahasa Indonesia adalah default jika Accept-Language tidak cocok.
+var catalog = map[string]map[string]string{
+	"id": {
+		"success.fetched":                  "Berhasil Mendapatkan Data",
+		"health.ok":                        "API berjalan normal",
+		"health.unavailable":               "API belum siap menerima request",
+		"question.created":                 "Soal berhasil dibuat",
+		"question.updated":                 "Soal berhasil diperbarui",
+		"question.deleted":                 "Soal berhasil dihapus",
+		"question.status_updated":          "Status soal berhasil diperbarui",
+		"review_comment.created":           "Komentar review berhasil dibuat",
+		"report.created":                   "Laporan berhasil dikirim",
+		"report.updated":                   "Laporan berhasil diperbarui",
+		"comment.created":                  "Komentar berhasil dibuat",
+		"comment.updated":                  "Komentar berhasil diperbarui",
+		"comment.deleted":                  "Komentar berhasil dihapus",
+		"error.internal":                   "terjadi masalah pada server",
+		"error.forbidden":                  "akses ditolak",
+		"error.not_found":                  "data tidak ditemukan",
+		"error.method_not_allowed":         "method {0} tidak didukung untuk URL ini",
+		"error.unauthorized":               "tidak terautentikasi",
+		"error.rate_limit":                 "batas permintaan terlampaui, coba lagi setelah: {0}",
+		"error.conflict":                   "data telah diubah oleh proses lain",
+		"error.invalid_id":                 "ID tidak valid",
+		"error.invalid_query":              "query parameter tidak valid",
+		"error.validation":                 "data yang dikirim tidak valid",
+		"error.single_json_value":          "body hanya boleh berisi satu nilai JSON",
+		"error.invalid_json":               "body bukan JSON yang valid",
+		"error.invalid_json_field":         "field {0} memiliki tipe data yang salah",
+		"error.unknown_json_field":         "field {0} tidak dikenal",
+		"error.body_too_large":             "body tidak boleh lebih dari {0} byte",
+		"error.status_transition":          "status tidak bisa diubah dari {0} ke {1}",
+		"error.report_reason_twk":          "alasan outdated_regulation hanya untuk soal TWK",
+		"error.resolution_note_required":   "resolution_note wajib diisi saat laporan ditutup",
+		"error.parent_not_found":           "parent_id tidak ditemukan",
+		"error.question_not_published":     "soal belum tayang",
+		"error.parent_wrong_question":      "parent_id bukan komentar pada soal ini",
+		"error.official_reply_only":        "hanya balasan yang bisa ditandai sebagai jawaban resmi",
+		"validation.required":              "wajib diisi",
+		"validation.oneof":                 "harus salah satu dari: {0}",
+		"validation.min_string":            "minimal {0} karakter",
+		"validation.min_items":             "minimal {0} item",
+		"validation.min_number":            "minimal {0}",
+		"validation.max_string":            "maksimal {0} karakter",
+		"validation.max_items":             "maksimal {0} item",
+		"validation.max_number":            "maksimal {0}",
+		"validation.len":                   "panjang harus {0} karakter",
+		"validation.url":                   "format URL tidak valid",
+		"validation.gt":                    "harus lebih besar dari {0}",
+		"validation.gte":                   "minimal {0}",
+		"validation.lte":                   "maksimal {0}",
+		"validation.integer":               "harus berupa angka bulat",
+		"validation.default":               "gagal pada aturan '{0}'",
+		"richtext.heading":                 "judul (heading) tidak didukung",
+		"richtext.image":                   "gambar tidak didukung, gunakan field URL gambar",
+		"richtext.link":                    "tautan tidak didukung",
+		"richtext.html":                    "HTML tidak didukung",
+		"richtext.code_block":              "blok kode tidak didukung",
+		"richtext.unsupported":             "markup {0} tidak didukung",
+		"richtext.tex_extra_brace":         "rumus memiliki kurung kurawal tutup yang berlebih",
+		"richtext.tex_unbalanced":          "rumus memiliki kurung kurawal yang tidak seimbang",
+		"richtext.tex_invalid_command":     "perintah rumus tidak valid di posisi {0}",
+		"richtext.tex_unsupported_command": "perintah rumus \\{0} tidak didukung",
+	},
+	"en": {
+		"success.fetched":                  "Data retrieved successfully",
+		"health.ok":                        "API is healthy",
+		"health.unavailable":               "API is not ready to serve requests",
+		"question.created":                 "Question created successfully",
+		"question.updated":                 "Question updated successfully",
+		"question.deleted":                 "Question deleted successfully",
+		"question.status_updated":          "Question status updated successfully",
+		"review_comment.created":           "Review comment created successfully",
+		"report.created":                   "Report created successfully",
+		"report.updated":                   "Report updated successfully",
+		"comment.created":                  "Comment created successfully",
+		"comment.updated":                  "Comment updated successfully",
+		"comment.deleted":                  "Comment deleted successfully",
+		"error.internal":                   "the server encountered a problem",
+		"error.forbidden":                  "forbidden",
+		"error.not_found":                  "not found",
+		"error.method_not_allowed":         "method {0} is not allowed for this URL",
+		"error.unauthorized":               "unauthorized",
+		"error.rate_limit":                 "rate limit exceeded, retry after: {0}",
+		"error.conflict":                   "the data was modified by another request",
+		"error.invalid_id":                 "invalid ID",
+		"error.invalid_query":              "invalid query parameters",
+		"error.validation":                 "the submitted data is invalid",
+		"error.single_json_value":          "body must only contain a single JSON value",
+		"error.invalid_json":               "body is not valid JSON",
+		"error.invalid_json_field":         "field {0} has the wrong type",
+		"error.unknown_json_field":         "unknown field {0}",
+		"error.body_too_large":             "body must not be larger than {0} bytes",
+		"error.status_transition":          "status cannot change from {0} to {1}",
+		"error.report_reason_twk":          "reason outdated_regulation is only allowed for TWK questions",
+		"error.resolution_note_required":   "resolution_note is required when closing a report",
+		"error.parent_not_found":           "parent_id not found",
+		"error.question_not_published":     "the question is not published",
+		"error.parent_wrong_question":      "parent_id is not a comment on this question",
+		"error.official_reply_only":        "only replies can be marked as the official answer",
+		"validation.required":              "is required",
+		"validation.oneof":                 "must be one of: {0}",
+		"validation.min_string":            "must be at least {0} characters",
+		"validation.min_items":             "must contain at least {0} items",
+		"validation.min_number":            "must be at least {0}",
+		"validation.max_string":            "must be at most {0} characters",
+		"validation.max_items":             "must contain at most {0} items",
+		"validation.max_number":            "must be at most {0}",
+		"validation.len":                   "must be exactly {0} characters long",
+		"validation.url":                   "must be a valid URL",
+		"validation.gt":                    "must be greater than {0}",
+		"validation.gte":                   "must be at least {0}",
+		"validation.lte":                   "must be at most {0}",
+		"validation.integer":               "must be an integer",
+		"validation.default":               "failed on the '{0}' rule",
+		"richtext.heading":                 "headings are not supported",
+		"richtext.image":                   "images are not supported, use the image URL field",
+		"richtext.link":                    "links are not supported",
+		"richtext.html":                    "HTML is not supported",
+		"richtext.code_block":              "code blocks are not supported",
+		"richtext.unsupported":             "{0} markup is not supported",
+		"richtext.tex_extra_brace":         "formula has an unmatched closing brace",
+		"richtext.tex_unbalanced":          "formula has unbalanced braces",
+		"richtext.tex_invalid_command":     "invalid formula command at position {0}",
+		"richtext.tex_unsupported_command": "formula command \\{0} is not supported",
+	},
+}
+
+var translator *ut.UniversalTranslator
+
+func init() {
+	idLocale := id.New()
+	translator = ut.New(idLocale, idLocale, en.New())
+
+	for locale, messages := range catalog {
+		trans, _ := translator.GetTranslator(locale)
+		for key, text := range messages {
+			if err := trans.Add(key, text, false); err != nil {
+				panic(err)
+			}
+		}
+	}
+}
+
+type translatorKey struct{}
+
+// localeMiddleware memilih bahasa response berdasarkan header Accept-Language
+func (app *application) localeMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		trans, _ := translator.FindTranslator(parseAcceptLanguage(r.Header.Get("Accept-Language"))...)
+
+		w.Header().Set("Content-Language", trans.Locale())
+		w.Header().Add("Vary", "Accept-Language")
+
+		ctx := context.WithValue(r.Context(), translatorKey{}, trans)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func getTranslator(r *http.Request) ut.Translator {
+	if trans, ok := r.Context().Value(translatorKey{}).(ut.Translator); ok {
+		return trans
+	}
+	return translator.GetFallback()
+}
+
+// t menerjemahkan key katalog sesuai bahasa request
+func (app *application) t(r *http.Request, key string, params ...string) string {
+	msg, err := getTranslator(r).T(key, params...)
+	if err != nil {
+		app.requestLogger(r).Warnw("missing translation", "key", key, "error", err.Error())
+		return key
+	}
+	return msg
+}
+
+// localizedError adalah error yang pesannya diambil dari katalog,
+// sehingga bisa ditampilkan ke user sesuai bahasa request
+type localizedError struct {
+	key    string
+	params []string
+}
+
+func newLocalizedError(key string, params ...string) error {
+	return &localizedError{key: key, params: params}
+}
+
+func (e *localizedError) Error() string {
+	msg, err := translator.GetFallback().T(e.key, e.params...)
+	if err != nil {
+		return e.key
+	}
+	return msg
+}
+
+// translateError mengubah error yang dikenal menjadi pesan sesuai bahasa request.
+// Error dari decoder JSON juga diterjemahkan supaya nama tipe Go tidak bocor ke client.
+func (app *application) translateError(r *http.Request, err error) string {
+	var le *localizedError
+	if errors.As(err, &le) {
+		return app.t(r, le.key, le.params...)
+	}
+
+	var re *richtext.Error
+	if errors.As(err, &re) {
+		if re.Arg == "" {
+			return app.t(r, "richtext."+re.Code)
+		}
+		return app.t(r, "richtext."+re.Code, re.Arg)
+	}
+
+	var typeErr *json.UnmarshalTypeError
+	var maxBytesErr *http.MaxBytesError
+
+	switch {
+	case errors.Is(err, store.ErrNotFound):
+		return app.t(r, "error.not_found")
+	case errors.Is(err, store.ErrConflict):
+		return app.t(r, "error.conflict")
+	case errors.As(err, &maxBytesErr):
+		return app.t(r, "error.body_too_large", strconv.FormatInt(maxBytesErr.Limit, 10))
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		return app.t(r, "error.invalid_json_field", jsonFieldPath(typeErr.Field))
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		return app.t(r, "error.unknown_json_field", field)
+	case errorCode(err, "") == codeInvalidJSON:
+		return app.t(r, "error.invalid_json")
+	}
+
+	return err.Error()
+}
+
+// jsonFieldPath mengubah path dari decoder JSON ("options.0.score") ke format
+// yang sama dengan key fields di error validasi ("options[0].score")
+func jsonFieldPath(field string) string {
+	parts := strings.Split(field, ".")
+
+	var b strings.Builder
+	for i, part := range parts {
+		if _, err := strconv.Atoi(part); err == nil {
+			b.WriteString("[" + part + "]")
+			continue
+		}
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		b.WriteString(part)
+	}
+
+	return b.String()
+}
+
+// parseAcceptLanguage mengurutkan bahasa di header Accept-Language berdasarkan
+// nilai q. Untuk tag seperti "en-US" ikut ditambahkan bahasa dasarnya ("en").
+func parseAcceptLanguage(header string) []string {
+	type lang struct {
+		tag string
+		q   float64
+	}
+
+	var langs []lang
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(v, 64)
+			// q=0 berarti bahasa itu ditolak client; q di luar 0..1 tidak valid (RFC 9110)
+			if err != nil || parsed <= 0 || parsed > 1 {
+				continue
+			}
+			q = parsed
+		}
+
+		langs = append(langs, lang{tag: strings.ToLower(tag), q: q})
+	}
+
+	sort.SliceStable(langs, func(i, j int) bool { return langs[i].q > langs[j].q })
+
+	locales := make([]string, 0, len(langs)*2)
+	for _, l := range langs {
+		locales = append(locales, strings.ReplaceAll(l.tag, "-", "_"))
+		if base, _, ok := strings.Cut(l.tag, "-"); ok {
+			locales = append(locales, base)
+		}
+	}
+
+	return locales
+}
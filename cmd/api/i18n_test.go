@@ -0,0 +1,117 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ReyviRahman/to-backend/internal/richtext"
+)
+
+// Key yang hilang di salah satu bahasa tidak membuat error, t() hanya
+// mengembalikan key mentah ke user. Test ini menjaga kedua katalog tetap sama.
+func TestCatalogLocalesHaveSameKeys(t *testing.T) {
+	placeholder := regexp.MustCompile(`\{\d+\}`)
+
+	for key, idText := range catalog["id"] {
+		enText, ok := catalog["en"][key]
+		if !ok {
+			t.Errorf("key %q is missing from the en catalog", key)
+			continue
+		}
+
+		idParams := placeholder.FindAllString(idText, -1)
+		enParams := placeholder.FindAllString(enText, -1)
+		sort.Strings(idParams)
+		sort.Strings(enParams)
+		if strings.Join(idParams, ",") != strings.Join(enParams, ",") {
+			t.Errorf("key %q uses parameters %v in id but %v in en", key, idParams, enParams)
+		}
+	}
+
+	for key := range catalog["en"] {
+		if _, ok := catalog["id"][key]; !ok {
+			t.Errorf("key %q is missing from the id catalog", key)
+		}
+	}
+}
+
+// TestCatalogCoversUsedKeys membaca source package ini dan memastikan setiap key
+// literal yang dipakai newLocalizedError, jsonResponse, dan t ada di kedua bahasa
+func TestCatalogCoversUsedKeys(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Posisi argumen key untuk setiap fungsi
+	keyArg := map[string]int{"newLocalizedError": 0, "jsonResponse": 3, "t": 1}
+
+	used := map[string]string{}
+	fset := token.NewFileSet()
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+
+		f, err := parser.ParseFile(fset, file, nil, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ast.Inspect(f, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+
+			var name string
+			switch fn := call.Fun.(type) {
+			case *ast.Ident:
+				name = fn.Name
+			case *ast.SelectorExpr:
+				name = fn.Sel.Name
+			}
+
+			i, ok := keyArg[name]
+			if !ok || len(call.Args) <= i {
+				return true
+			}
+			if lit, ok := call.Args[i].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+				key, _ := strconv.Unquote(lit.Value)
+				used[key] = fset.Position(lit.Pos()).String()
+			}
+			return true
+		})
+	}
+
+	// Key yang disusun saat runtime
+	for _, code := range []string{
+		richtext.CodeHeading, richtext.CodeImage, richtext.CodeLink, richtext.CodeHTML,
+		richtext.CodeCodeBlock, richtext.CodeUnsupported, richtext.CodeTeXExtraBrace,
+		richtext.CodeTeXUnbalanced, richtext.CodeTeXInvalid, richtext.CodeTeXUnsupported,
+	} {
+		used["richtext."+code] = "richtext.Error"
+	}
+	for _, tag := range []string{"min_string", "min_items", "min_number", "max_string", "max_items", "max_number", "gt", "gte", "lte"} {
+		used["validation."+tag] = "parseValidationError"
+	}
+
+	if len(used) < 20 {
+		t.Fatalf("found only %d keys, the source scan is probably broken", len(used))
+	}
+
+	for key, pos := range used {
+		for locale, messages := range catalog {
+			if _, ok := messages[key]; !ok {
+				t.Errorf("%s: key %q is missing from the %s catalog", pos, key, locale)
+			}
+		}
+	}
+}
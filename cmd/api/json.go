@@ -2,8 +2,6 @@ package main
 
 import (
 	"encoding/json"
-	"errors"
-	"fmt"
 	"io"
 	"net/http"
 	"reflect"
@@ -28,35 +26,62 @@ func init() {
 	})
 }
 
-func (app *application) parseValidationError(err error) map[string]string {
-	errors := make(map[string]string)
+// parseValidationError mengembalikan error per field. Code berisi nama aturan
+// validator (required, oneof, min, dst) sehingga stabil untuk dipakai client.
+func (app *application) parseValidationError(r *http.Request, err error) map[string]fieldError {
+	errors := make(map[string]fieldError)
 
 	if validationErrors, ok := err.(validator.ValidationErrors); ok {
 		for _, v := range validationErrors {
+			var message string
+
 			// v.Tag() akan berisi "required", "oneof", "min", dll.
 			switch v.Tag() {
 			case "required":
-				errors[v.Field()] = "wajib diisi"
+				message = app.t(r, "validation.required")
 			case "oneof":
 				// v.Param() akan berisi nilai yang diperbolehkan (misal: "TIU TWK TKP")
-				errors[v.Field()] = fmt.Sprintf("harus salah satu dari: %s", v.Param())
-			case "min":
-				errors[v.Field()] = fmt.Sprintf("minimal %s karakter", v.Param())
-			case "max":
-				errors[v.Field()] = fmt.Sprintf("maksimal %s karakter", v.Param())
+				message = app.t(r, "validation.oneof", v.Param())
+			case "min", "max":
+				message = app.t(r, "validation."+v.Tag()+"_"+sizeKind(v.Kind()), v.Param())
 			case "len":
-				errors[v.Field()] = fmt.Sprintf("panjang harus %s karakter", v.Param())
+				message = app.t(r, "validation.len", v.Param())
 			case "url":
-				errors[v.Field()] = "format URL tidak valid"
+				message = app.t(r, "validation.url")
+			case "gt", "gte", "lte":
+				message = app.t(r, "validation."+v.Tag(), v.Param())
 			default:
-				errors[v.Field()] = fmt.Sprintf("gagal pada aturan '%s'", v.Tag())
+				message = app.t(r, "validation.default", v.Tag())
 			}
+
+			errors[fieldPath(v)] = fieldError{Code: v.Tag(), Message: message}
 		}
 	}
 
 	return errors
 }
 
+// fieldPath mengembalikan path field JSON tanpa nama struct payload, misal
+// "options[0].code", supaya error dari item yang berbeda tidak saling menimpa
+func fieldPath(fe validator.FieldError) string {
+	if _, path, ok := strings.Cut(fe.Namespace(), "."); ok {
+		return path
+	}
+	return fe.Field()
+}
+
+// sizeKind menentukan satuan untuk aturan min/max: panjang string, jumlah item, atau nilai angka
+func sizeKind(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return "string"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return "items"
+	default:
+		return "number"
+	}
+}
+
 func writeJSON(w http.ResponseWriter, status int, data any) error {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
@@ -79,22 +104,22 @@ func readJSON(w http.ResponseWriter, r *http.Request, data any) error {
 	// Pastikan tidak ada data tambahan setelah JSON selesai di-decode
 	err = decoder.Decode(&struct{}{})
 	if err != io.EOF {
-		return errors.New("body must only contain a single JSON value")
+		return newLocalizedError("error.single_json_value")
 	}
 
 	return nil
 }
 
-func writeJSONError(w http.ResponseWriter, status int, message string) error {
+func writeJSONError(w http.ResponseWriter, status int, apiErr apiError) error {
 	type envelope struct {
-		Error string `json:"error"`
+		Error apiError `json:"error"`
 	}
 
-	return writeJSON(w, status, &envelope{Error: message})
+	return writeJSON(w, status, &envelope{Error: apiErr})
 }
 
-// Tambahkan parameter 'headers ...any' di akhir
-func (app *application) jsonResponse(w http.ResponseWriter, status int, message string, data any, meta ...any) error {
+// message adalah key katalog (lihat i18n.go) yang diterjemahkan sesuai bahasa request
+func (app *application) jsonResponse(w http.ResponseWriter, r *http.Request, status int, message string, data any, meta ...any) error {
 
 	// Kita buat struct baru yang punya field Meta dengan tag "omitempty"
 	// omitempty artinya: kalau nil, field ini gak bakal muncul di JSON
@@ -103,7 +128,7 @@ func (app *application) jsonResponse(w http.ResponseWriter, status int, message
 		Data    any    `json:"data"`
 		Meta    any    `json:"meta,omitempty"`
 	}{
-		Message: message,
+		Message: app.t(r, message),
 		Data:    data,
 	}
 
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestReadJSON(t *testing.T) {
+	type payload struct {
+		Name  string `json:"name"`
+		Count int    `json:"count"`
+	}
+
+	app := newTestApplication(t)
+
+	tests := []struct {
+		name    string
+		body    string
+		wantErr bool
+		code    string
+		message string
+	}{
+		{name: "valid", body: `{"name":"a","count":1}`},
+		{name: "trailing whitespace", body: "{\"name\":\"a\"}\n\t "},
+		{name: "empty body", body: "", wantErr: true, code: codeInvalidJSON, message: "body bukan JSON yang valid"},
+		{name: "malformed", body: `{"name":`, wantErr: true, code: codeInvalidJSON, message: "body bukan JSON yang valid"},
+		{name: "syntax error", body: `{"name" "a"}`, wantErr: true, code: codeInvalidJSON, message: "body bukan JSON yang valid"},
+		{name: "wrong type", body: `{"count":"satu"}`, wantErr: true, code: codeInvalidJSON, message: "field count memiliki tipe data yang salah"},
+		{name: "wrong top-level type", body: `[1]`, wantErr: true, code: codeInvalidJSON, message: "body bukan JSON yang valid"},
+		{name: "unknown field", body: `{"nama":"a"}`, wantErr: true, code: codeInvalidJSON, message: "field nama tidak dikenal"},
+		{name: "two values", body: `{"name":"a"}{"name":"b"}`, wantErr: true, code: "single_json_value", message: "body hanya boleh berisi satu nilai JSON"},
+		{name: "trailing garbage", body: `{"name":"a"} x`, wantErr: true, code: "single_json_value", message: "body hanya boleh berisi satu nilai JSON"},
+		{name: "too large", body: `{"name":"` + strings.Repeat("a", 1_048_578) + `"}`, wantErr: true, code: codeBodyTooLarge, message: "body tidak boleh lebih dari 1048578 byte"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			rec := httptest.NewRecorder()
+
+			var p payload
+			err := readJSON(rec, req, &p)
+
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if p.Name != "a" {
+					t.Errorf("name = %q, want a", p.Name)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if got := errorCode(err, codeBadRequest); got != tt.code {
+				t.Errorf("errorCode = %q, want %q (error: %v)", got, tt.code, err)
+			}
+			if got := app.translateError(req, err); got != tt.message {
+				t.Errorf("message = %q, want %q", got, tt.message)
+			}
+		})
+	}
+}
+
+func TestReadJSONEmptyBodyIsEOF(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(""))
+
+	var v map[string]any
+	if err := readJSON(httptest.NewRecorder(), req, &v); !errors.Is(err, io.EOF) {
+		t.Errorf("error = %v, want io.EOF", err)
+	}
+}
+
+func TestJSONResponseEnvelope(t *testing.T) {
+	app := newTestApplication(t)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	rec := httptest.NewRecorder()
+
+	if err := app.jsonResponse(rec, req, http.StatusOK, "success.fetched", []int{1}); err != nil {
+		t.Fatal(err)
+	}
+
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := body["meta"]; ok {
+		t.Errorf("meta should be omitted when not given: %s", rec.Body.String())
+	}
+	if string(body["data"]) != "[1]" {
+		t.Errorf("data = %s, want [1]", body["data"])
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+}
@@ -1,68 +1,88 @@
 package main
 
 import (
-	"log"
+	"context"
 	"os"
-	"strconv"
+	"time"
 
+	"github.com/ReyviRahman/to-backend/internal/config"
 	"github.com/ReyviRahman/to-backend/internal/db"
+	"github.com/ReyviRahman/to-backend/internal/ratelimit"
 	"github.com/ReyviRahman/to-backend/internal/store"
-	"github.com/joho/godotenv"
 	_ "github.com/lib/pq"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 	"go.uber.org/zap"
 )
 
-func getEnvInt(key string) int {
-	valStr := os.Getenv(key)
-	if valStr == "" {
-		log.Fatalf("CRITICAL ERROR: Environment variable '%s' wajib diisi!", key)
-	}
-	valInt, err := strconv.Atoi(valStr)
-	if err != nil {
-		log.Fatalf("CRITICAL ERROR: Environment variable '%s' harus berupa ANGKA (integer). Nilai saat ini: %s", key, valStr)
-	}
-	return valInt
-}
-
 func main() {
-	err := godotenv.Load()
+	logger := zap.Must(zap.NewProduction()).Sugar()
+
+	cfg, err := config.Load()
 	if err != nil {
-		log.Fatal("Error loading .env file")
+		// Semua masalah konfigurasi dilaporkan sekaligus, satu per baris
+		logger.Fatalf("invalid configuration:\n%s", err)
 	}
+	logger.Infow("effective config", "config", cfg.Redacted())
 
-	cfg := config{
-		addr: os.Getenv("ADDR"),
-		db: dbConfig{
-			addr:         os.Getenv("DB_DSN"),
-			maxOpenConns: getEnvInt("DB_MAX_OPEN_CONNS"),
-			maxIdleConns: getEnvInt("DB_MAX_IDLE_CONNS"),
-			maxIdleTime:  os.Getenv("DB_MAX_IDLE_TIME"),
-		},
+	tracerProvider, err := setupTracing(context.Background(), cfg.Tracing.Exporter)
+	if err != nil {
+		logger.Fatal(err)
 	}
 
-	logger := zap.Must(zap.NewProduction()).Sugar()
-	defer logger.Sync()
-
 	db, err := db.New(
-		cfg.db.addr,
-		cfg.db.maxOpenConns,
-		cfg.db.maxIdleConns,
-		cfg.db.maxIdleTime,
+		cfg.DB.DSN,
+		cfg.DB.MaxOpenConns,
+		cfg.DB.MaxIdleConns,
+		cfg.DB.MaxIdleTime.String(),
 	)
 	if err != nil {
 		logger.Fatal(err)
 	}
 
-	defer db.Close()
 	logger.Info("database connection pool established")
+	prometheus.MustRegister(collectors.NewDBStatsCollector(db, "postgres"))
 	store := store.NewStorage(db)
 
+	rateLimiter := ratelimit.NewMemoryLimiter(time.Minute)
+
 	app := &application{
-		config: cfg,
-		store:  store,
-		logger: logger,
+		config:      cfg,
+		db:          db,
+		store:       store,
+		logger:      logger,
+		rateLimiter: rateLimiter,
 	}
 
 	mux := app.mount()
-	logger.Fatal(app.run(mux))
+	runErr := app.run(mux)
+	if runErr != nil {
+		logger.Errorw("server error", "error", runErr.Error())
+	}
+
+	// Urutan penting: hentikan worker di background dulu, baru tutup database,
+	// dan terakhir flush log supaya semua log shutdown ikut tertulis.
+	logger.Info("stopping background workers")
+	rateLimiter.Stop()
+
+	if tracerProvider != nil {
+		logger.Info("flushing traces")
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := tracerProvider.Shutdown(ctx); err != nil {
+			logger.Errorw("failed to flush traces", "error", err.Error())
+		}
+		cancel()
+	}
+
+	logger.Info("closing database connection pool")
+	if err := db.Close(); err != nil {
+		logger.Errorw("failed to close database connection pool", "error", err.Error())
+	}
+
+	logger.Info("shutdown complete")
+	logger.Sync()
+
+	if runErr != nil {
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "tryout",
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Durasi request HTTP per route chi.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	httpRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "tryout",
+		Subsystem: "http",
+		Name:      "requests_total",
+		Help:      "Jumlah request HTTP per route dan status code.",
+	}, []string{"method", "route", "status"})
+)
+
+// Counter untuk kejadian bisnis
+var (
+	questionsCreatedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "tryout",
+		Name:      "questions_created_total",
+		Help:      "Jumlah soal yang dibuat per kategori.",
+	}, []string{"category"})
+
+	questionStatusTransitionsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "tryout",
+		Name:      "question_status_transitions_total",
+		Help:      "Jumlah perpindahan status soal.",
+	}, []string{"from", "to"})
+
+	reportsSubmittedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "tryout",
+		Name:      "reports_submitted_total",
+		Help:      "Jumlah laporan kesalahan soal dari siswa per alasan.",
+	}, []string{"reason"})
+
+	commentsPostedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "tryout",
+		Name:      "comments_posted_total",
+		Help:      "Jumlah komentar diskusi yang dikirim.",
+	})
+)
+
+// metricsMiddleware mencatat latency dan status code per route pattern chi
+// (misal "/questions/{id}"), bukan per URL asli, supaya jumlah label tetap kecil
+func (app *application) metricsMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+		next.ServeHTTP(ww, r)
+
+		route := chi.RouteContext(r.Context()).RoutePattern()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		// Handler yang tidak menulis apa pun tetap dikirim sebagai 200 oleh net/http
+		status := ww.Status()
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		httpRequestDuration.WithLabelValues(r.Method, route).Observe(time.Since(start).Seconds())
+		httpRequestsTotal.WithLabelValues(r.Method, route, strconv.Itoa(status)).Inc()
+	})
+}
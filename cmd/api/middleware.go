@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"runtime/debug"
+	"strconv"
+	"time"
+
+	"github.com/ReyviRahman/to-backend/internal/config"
+	"github.com/ReyviRahman/to-backend/internal/ratelimit"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// requestLogger menambahkan request ID, method, dan path ke setiap log
+// supaya semua log dari satu request bisa dicari bersama
+func (app *application) requestLogger(r *http.Request) *zap.SugaredLogger {
+	fields := []any{
+		"request_id", middleware.GetReqID(r.Context()),
+		"method", r.Method,
+		"path", r.URL.Path,
+	}
+
+	// trace_id dipakai untuk mencocokkan log dengan trace di backend tracing
+	if sc := trace.SpanContextFromContext(r.Context()); sc.IsValid() {
+		fields = append(fields, "trace_id", sc.TraceID().String(), "span_id", sc.SpanID().String())
+	}
+
+	return app.logger.With(fields...)
+}
+
+// accessLog menggantikan middleware.Logger bawaan chi supaya access log
+// memakai format zap yang sama dengan log aplikasi lainnya
+func (app *application) accessLog(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+
+		defer func() {
+			status := ww.Status()
+			if status == 0 {
+				status = http.StatusOK
+			}
+
+			fields := []any{
+				"route", chi.RouteContext(r.Context()).RoutePattern(),
+				"status", status,
+				"bytes", ww.BytesWritten(),
+				"latency_ms", float64(time.Since(start).Microseconds()) / 1000,
+				"remote_ip", r.RemoteAddr,
+				"user_agent", r.UserAgent(),
+			}
+
+			logger := app.requestLogger(r)
+			switch {
+			case status >= 500:
+				logger.Errorw("request completed", fields...)
+			case status >= 400:
+				logger.Warnw("request completed", fields...)
+			default:
+				logger.Infow("request completed", fields...)
+			}
+		}()
+
+		next.ServeHTTP(ww, r)
+	})
+}
+
+// recoverPanic menggantikan middleware.Recoverer bawaan chi supaya panic
+// dicatat lewat zap dan client tetap menerima format error yang sama
+func (app *application) recoverPanic(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+
+			// http.ErrAbortHandler dipakai untuk memutus response dengan sengaja
+			if rec == http.ErrAbortHandler {
+				panic(rec)
+			}
+
+			w.Header().Set("Connection", "close")
+			app.internalServerError(w, r, &panicError{value: rec, stack: debug.Stack()})
+		}()
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// panicError membawa stack trace dari panic supaya internalServerError bisa
+// mencatatnya sebagai field zap, bukan dicetak terpisah ke stderr
+type panicError struct {
+	value any
+	stack []byte
+}
+
+func (e *panicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.value)
+}
+
+// rateLimit membatasi jumlah request per client untuk route tertentu.
+// name dipakai sebagai bagian dari key supaya setiap route punya bucket sendiri.
+func (app *application) rateLimit(name string, rule config.RateLimitRule) func(http.Handler) http.Handler {
+	limit := ratelimit.Limit{Requests: rule.Requests, Window: rule.Window}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !app.config.RateLimit.Enabled {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			res, err := app.rateLimiter.Allow(r.Context(), name+":"+rateLimitKey(r), limit)
+			if err != nil {
+				// Jika backend limiter bermasalah, request tetap dilayani (fail open)
+				app.requestLogger(r).Errorw("rate limiter error", "error", err.Error())
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("RateLimit-Limit", strconv.Itoa(res.Limit))
+			w.Header().Set("RateLimit-Remaining", strconv.Itoa(res.Remaining))
+			w.Header().Set("RateLimit-Reset", ceilSeconds(res.ResetAfter))
+
+			if !res.Allowed {
+				app.rateLimitExceededResponse(w, r, ceilSeconds(res.RetryAfter))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// rateLimitKey mengidentifikasi client berdasarkan IP (sudah diisi middleware.RealIP).
+// Belum ada autentikasi, jadi user yang login belum bisa punya bucket sendiri.
+func rateLimitKey(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+func ceilSeconds(d time.Duration) string {
+	return strconv.Itoa(int(math.Ceil(d.Seconds())))
+}
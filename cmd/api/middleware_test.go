@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ReyviRahman/to-backend/internal/config"
+	"github.com/ReyviRahman/to-backend/internal/models"
+	"github.com/go-chi/chi/v5/middleware"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRateLimit(t *testing.T) {
+	app := newTestApplication(t)
+	app.config.RateLimit = config.RateLimitConfig{
+		Enabled: true,
+		Global:  config.RateLimitRule{Requests: 5, Window: time.Minute},
+		Strict:  config.RateLimitRule{Requests: 2, Window: time.Minute},
+	}
+	q := seedQuestion(t, app, "TIU", "Soal untuk uji rate limit", models.QuestionStatusPublished)
+	h := app.mount()
+	target := fmt.Sprintf("/questions/%d/reports", q.ID)
+	body := `{"source":"practice","reason":"typo"}`
+
+	for i := 0; i < 2; i++ {
+		rec := doRequest(t, h, http.MethodPost, target, body)
+		assertStatus(t, rec, http.StatusCreated)
+	}
+
+	rec := doRequest(t, h, http.MethodPost, target, body)
+	assertError(t, rec, http.StatusTooManyRequests, codeRateLimitExceeded)
+	if rec.Header().Get("Retry-After") == "" {
+		t.Error("Retry-After header is missing")
+	}
+	if got := rec.Header().Get("RateLimit-Limit"); got != "2" {
+		t.Errorf("RateLimit-Limit = %q, want 2", got)
+	}
+
+	// Bucket strict terpisah dari bucket global, yang baru terpakai 3 dari 5
+	rec = doRequest(t, h, http.MethodGet, "/questions", "")
+	assertStatus(t, rec, http.StatusOK)
+	if got := rec.Header().Get("RateLimit-Remaining"); got != "1" {
+		t.Errorf("RateLimit-Remaining = %q, want 1", got)
+	}
+
+	// Probe tidak ikut dibatasi
+	for i := 0; i < 10; i++ {
+		assertStatus(t, doRequest(t, h, http.MethodGet, "/healthz", ""), http.StatusOK)
+	}
+}
+
+func TestRecoverPanic(t *testing.T) {
+	app := newTestApplication(t)
+	h := middleware.RequestID(app.localeMiddleware(app.recoverPanic(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Accept-Language", "en")
+	rec := doRequestWith(t, h, req)
+
+	res := assertError(t, rec, http.StatusInternalServerError, codeInternal)
+	if strings.Contains(rec.Body.String(), "boom") {
+		t.Errorf("panic value leaked to the client: %s", rec.Body.String())
+	}
+	if res.Error.Message != "the server encountered a problem" {
+		t.Errorf("message = %q, want the translated internal error", res.Error.Message)
+	}
+}
+
+// Panic dan stack trace-nya harus masuk ke log zap yang sama dengan request
+// lain, bukan dicetak terpisah ke stderr
+func TestRecoverPanicLogsStack(t *testing.T) {
+	core, logs := observer.New(zap.ErrorLevel)
+	app := newTestApplication(t)
+	app.logger = zap.New(core).Sugar()
+
+	h := middleware.RequestID(app.recoverPanic(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})))
+	doRequest(t, h, http.MethodGet, "/soal", "")
+
+	entries := logs.All()
+	if len(entries) != 1 {
+		t.Fatalf("got %d log entries, want 1", len(entries))
+	}
+
+	fields := entries[0].ContextMap()
+	if fields["error"] != "panic: boom" || fields["path"] != "/soal" || fields["request_id"] == "" {
+		t.Errorf("log fields = %v, want the panic with request fields", fields)
+	}
+	if stack, _ := fields["stack"].(string); !strings.Contains(stack, "TestRecoverPanicLogsStack") {
+		t.Errorf("stack = %q, want the panicking handler in the stack trace", stack)
+	}
+}
+
+// http.ErrAbortHandler harus tetap diteruskan supaya server memutus koneksi
+func TestRecoverPanicAbortHandler(t *testing.T) {
+	app := newTestApplication(t)
+	h := app.recoverPanic(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	defer func() {
+		if rec := recover(); rec != http.ErrAbortHandler {
+			t.Errorf("recovered %v, want http.ErrAbortHandler", rec)
+		}
+	}()
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
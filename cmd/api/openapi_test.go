@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// TestOpenAPIMatchesRoutes memastikan setiap route di mount() terdokumentasi
+// di openapi.json, dan sebaliknya tidak ada path di dokumen yang sudah dihapus.
+func TestOpenAPIMatchesRoutes(t *testing.T) {
+	var spec struct {
+		OpenAPI string                                `json:"openapi"`
+		Paths   map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(openAPISpec, &spec); err != nil {
+		t.Fatalf("openapi.json is not valid JSON: %v", err)
+	}
+	if !strings.HasPrefix(spec.OpenAPI, "3.1") {
+		t.Fatalf("openapi version = %q, want 3.1.x", spec.OpenAPI)
+	}
+
+	documented := make(map[string]bool)
+	for path, item := range spec.Paths {
+		for method := range item {
+			// Key lain di path item (parameters, summary, dll) bukan operation
+			if !isHTTPMethod(method) {
+				continue
+			}
+			documented[strings.ToUpper(method)+" "+path] = true
+		}
+	}
+
+	app := &application{}
+	router, ok := app.mount().(chi.Routes)
+	if !ok {
+		t.Fatal("mount() does not return a chi router")
+	}
+
+	registered := make(map[string]bool)
+	err := chi.Walk(router, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		// /metrics didaftarkan dengan Handle sehingga terdaftar untuk semua method,
+		// tapi hanya GET yang dipakai Prometheus
+		if route == "/metrics" && method != http.MethodGet {
+			return nil
+		}
+		registered[method+" "+normalizeRoute(route)] = true
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("walking routes: %v", err)
+	}
+
+	for _, op := range sortedKeys(registered) {
+		if !documented[op] {
+			t.Errorf("route %s is registered but missing from openapi.json", op)
+		}
+	}
+	for _, op := range sortedKeys(documented) {
+		if !registered[op] {
+			t.Errorf("openapi.json documents %s but no such route is registered", op)
+		}
+	}
+}
+
+// normalizeRoute menghapus slash di akhir pattern chi ("/questions/" -> "/questions")
+// supaya sama dengan penulisan path di dokumen OpenAPI
+func normalizeRoute(route string) string {
+	if route == "/" {
+		return route
+	}
+	return strings.TrimSuffix(route, "/")
+}
+
+func isHTTPMethod(s string) bool {
+	switch strings.ToUpper(s) {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	return false
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
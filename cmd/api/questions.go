@@ -2,10 +2,12 @@ package main
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
 
 	"github.com/ReyviRahman/to-backend/internal/models"
+	"github.com/ReyviRahman/to-backend/internal/richtext"
 	"github.com/ReyviRahman/to-backend/internal/store"
 	"github.com/go-chi/chi/v5"
 )
@@ -16,7 +18,8 @@ type CreateQuestionPayload struct {
 	// Wajib diisi, dan harus salah satu dari TIU, TWK, atau TKP
 	Category string `json:"category" validate:"required,oneof=TIU TWK TKP"`
 
-	// Wajib diisi, minimal 10 karakter, maksimal 500 karakter
+	// Wajib diisi, minimal 10 karakter, maksimal 500 karakter.
+	// QuestionText, Option.Text, dan Explanation mendukung subset markdown + LaTeX ($...$)
 	QuestionText string `json:"question_text" validate:"required,min=10,max=500"`
 
 	// Field ini opsional (boleh kosong), tapi jika diisi harus berupa URL yang valid
@@ -37,6 +40,54 @@ type OptionPayload struct {
 	Score int `json:"score" validate:"min=0,max=5"`
 }
 
+// toQuestion memvalidasi markup (markdown + LaTeX) di setiap field konten
+// dan menyimpan hasil render HTML-nya. Error dikembalikan per field.
+func (p CreateQuestionPayload) toQuestion() (*models.Question, map[string]error) {
+	fieldErrors := make(map[string]error)
+
+	render := func(field, source string) string {
+		html, err := richtext.Render(source)
+		if err != nil {
+			fieldErrors[field] = err
+		}
+		return html
+	}
+
+	options := make(models.QuestionOptions, len(p.Options))
+	for i, opt := range p.Options {
+		options[i] = models.Option{
+			Code:     opt.Code,
+			Text:     opt.Text,
+			TextHTML: render(fmt.Sprintf("options[%d].text", i), opt.Text),
+			Score:    opt.Score,
+		}
+	}
+
+	question := &models.Question{
+		Category:         p.Category,
+		QuestionText:     p.QuestionText,
+		QuestionTextHTML: render("question_text", p.QuestionText),
+		Options:          options,
+		Explanation:      p.Explanation,
+		ExplanationHTML:  render("explanation", p.Explanation),
+	}
+
+	return question, fieldErrors
+}
+
+type UpdateQuestionStatusPayload struct {
+	Status string `json:"status" validate:"required,oneof=draft in_review approved published retired"`
+}
+
+// readIDParam membaca parameter {id} dari URL dan memastikan nilainya valid
+func readIDParam(r *http.Request) (int64, error) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil || id < 1 {
+		return 0, newLocalizedError("error.invalid_id")
+	}
+	return id, nil
+}
+
 func (app *application) createQuestionHandler(w http.ResponseWriter, r *http.Request) {
 	var payload CreateQuestionPayload
 
@@ -54,20 +105,10 @@ func (app *application) createQuestionHandler(w http.ResponseWriter, r *http.Req
 
 	// 3. Mapping: Payload -> Model
 	// Di sini kita pindahkan data dari struct "input" ke struct "database"
-
-	options := make(models.QuestionOptions, len(payload.Options))
-	for i, opt := range payload.Options {
-		options[i] = models.Option{
-			Code:  opt.Code,
-			Text:  opt.Text,
-			Score: opt.Score,
-		}
-	}
-	question := &models.Question{
-		Category:     payload.Category,
-		QuestionText: payload.QuestionText,
-		Options:      options,
-		Explanation:  payload.Explanation,
+	question, fieldErrors := payload.toQuestion()
+	if len(fieldErrors) > 0 {
+		app.fieldErrorResponse(w, r, fieldErrors)
+		return
 	}
 
 	// 4. Simpan ke Database via Store
@@ -76,17 +117,21 @@ func (app *application) createQuestionHandler(w http.ResponseWriter, r *http.Req
 		app.internalServerError(w, r, err)
 		return
 	}
+	questionsCreatedTotal.WithLabelValues(question.Category).Inc()
 
 	// 5. Kirim Response (balikan object 'question' yang sudah ada ID-nya)
-	if err := app.jsonResponse(w, http.StatusCreated, "Question created successfully", question); err != nil {
+	if err := app.jsonResponse(w, r, http.StatusCreated, "question.created", question); err != nil {
 		app.internalServerError(w, r, err)
 	}
 }
 
+// getQuestionHandler hanya menampilkan soal yang sudah tayang kecuali
+// status diminta eksplisit. Editor memakai ?status=all untuk semua soal.
 func (app *application) getQuestionHandler(w http.ResponseWriter, r *http.Request) {
 	qq := store.PaginatedQuestionQuery{
 		Limit:  20,
 		Offset: 0,
+		Status: models.QuestionStatusPublished,
 	}
 
 	qq, err := qq.Parse(r)
@@ -105,19 +150,19 @@ func (app *application) getQuestionHandler(w http.ResponseWriter, r *http.Reques
 	questions, meta, err := app.store.Questions.GetQuestions(ctx, qq)
 	if err != nil {
 		app.internalServerError(w, r, err)
+		return
 	}
 
-	err = app.jsonResponse(w, http.StatusOK, "Berhasil Mendapatkan Data", questions, meta)
+	err = app.jsonResponse(w, r, http.StatusOK, "success.fetched", questions, meta)
 	if err != nil {
 		app.internalServerError(w, r, err)
 	}
 }
 
 func (app *application) updateQuestionHandler(w http.ResponseWriter, r *http.Request) {
-	idStr := chi.URLParam(r, "id")
-	id, err := strconv.ParseInt(idStr, 10, 64)
-	if err != nil || id < 1 {
-		app.badRequestResponse(w, r, errors.New("ID tidak valid"))
+	id, err := readIDParam(r)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
 		return
 	}
 
@@ -132,50 +177,102 @@ func (app *application) updateQuestionHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	options := make(models.QuestionOptions, len(payload.Options))
-	for i, opt := range payload.Options {
-		options[i] = models.Option{
-			Code:  opt.Code,
-			Text:  opt.Text,
-			Score: opt.Score,
+	question, fieldErrors := payload.toQuestion()
+	if len(fieldErrors) > 0 {
+		app.fieldErrorResponse(w, r, fieldErrors)
+		return
+	}
+
+	ctx := r.Context()
+	current, err := app.store.Questions.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			app.notFoundResponse(w, r, err)
+			return
 		}
+		app.internalServerError(w, r, err)
+		return
 	}
 
-	question := &models.Question{
-		ID:           id,
-		Category:     payload.Category,
-		QuestionText: payload.QuestionText,
-		Options:      options,
-		Explanation:  payload.Explanation,
+	question.ID = id
+	question.Status = current.Status
+	question.QuestionImageURL = current.QuestionImageURL
+	question.ExplanationImageURL = current.ExplanationImageURL
+	question.CreatedAt = current.CreatedAt
+
+	// Isi yang sudah disetujui tidak boleh berubah tanpa review ulang:
+	// soal approved kembali ke draft, soal published harus di-retire dulu
+	status := current.Status
+	if models.QuestionEditRequiresReview(current.Status) {
+		if !models.CanTransitionQuestionStatus(current.Status, models.QuestionStatusDraft) {
+			app.conflictResponse(w, r, newLocalizedError("error.status_transition", current.Status, models.QuestionStatusDraft))
+			return
+		}
+		status = models.QuestionStatusDraft
 	}
 
-	ctx := r.Context()
-	if err := app.store.Questions.Update(ctx, question); err != nil {
-		if err.Error() == "data tidak ditemukan" {
-			app.notFoundResponse(w, r, err)
+	if err := app.store.Questions.Update(ctx, question, status); err != nil {
+		if errors.Is(err, store.ErrConflict) {
+			app.conflictResponse(w, r, err)
 			return
 		}
 		app.internalServerError(w, r, err)
 		return
 	}
+	if status != current.Status {
+		questionStatusTransitionsTotal.WithLabelValues(current.Status, status).Inc()
+	}
 
-	if err := app.jsonResponse(w, http.StatusOK, "Question Update successfully", question); err != nil {
+	if err := app.jsonResponse(w, r, http.StatusOK, "question.updated", question); err != nil {
 		app.internalServerError(w, r, err)
 	}
 
 }
 
 func (app *application) deleteQuestionHandler(w http.ResponseWriter, r *http.Request) {
-	idStr := chi.URLParam(r, "id")
-	id, err := strconv.ParseInt(idStr, 10, 64)
-	if err != nil || id < 1 {
-		app.badRequestResponse(w, r, errors.New("ID tidak valid"))
+	id, err := readIDParam(r)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
 		return
 	}
 
 	ctx := r.Context()
 	if err := app.store.Questions.Delete(ctx, id); err != nil {
-		if err.Error() == "data tidak ditemukan" {
+		if errors.Is(err, store.ErrNotFound) {
+			app.notFoundResponse(w, r, err)
+			return
+		}
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := app.jsonResponse(w, r, http.StatusOK, "question.deleted", nil); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+func (app *application) updateQuestionStatusHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := readIDParam(r)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	var payload UpdateQuestionStatusPayload
+	if err := readJSON(w, r, &payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(payload); err != nil {
+		app.validationErrorResponse(w, r, err)
+		return
+	}
+
+	ctx := r.Context()
+	question, err := app.store.Questions.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
 			app.notFoundResponse(w, r, err)
 			return
 		}
@@ -183,7 +280,23 @@ func (app *application) deleteQuestionHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	if err := app.jsonResponse(w, http.StatusOK, "Question deleted successfully", nil); err != nil {
+	if !models.CanTransitionQuestionStatus(question.Status, payload.Status) {
+		app.conflictResponse(w, r, newLocalizedError("error.status_transition", question.Status, payload.Status))
+		return
+	}
+
+	from := question.Status
+	if err := app.store.Questions.UpdateStatus(ctx, question, payload.Status); err != nil {
+		if errors.Is(err, store.ErrConflict) {
+			app.conflictResponse(w, r, err)
+			return
+		}
+		app.internalServerError(w, r, err)
+		return
+	}
+	questionStatusTransitionsTotal.WithLabelValues(from, question.Status).Inc()
+
+	if err := app.jsonResponse(w, r, http.StatusOK, "question.status_updated", question); err != nil {
 		app.internalServerError(w, r, err)
 	}
 }
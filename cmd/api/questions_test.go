@@ -0,0 +1,438 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ReyviRahman/to-backend/internal/models"
+	"github.com/ReyviRahman/to-backend/internal/store"
+)
+
+func TestCreateQuestion(t *testing.T) {
+	app := newTestApplication(t)
+	h := app.mount()
+
+	rec := doRequest(t, h, http.MethodPost, "/questions", validQuestionJSON)
+	assertStatus(t, rec, http.StatusCreated)
+
+	res := decodeResponse(t, rec)
+	if res.Message != "Question created successfully" {
+		t.Errorf("message = %q", res.Message)
+	}
+
+	var q models.Question
+	decodeData(t, res, &q)
+	if q.ID != 1 || q.Status != models.QuestionStatusDraft {
+		t.Errorf("got id %d status %q, want id 1 status draft", q.ID, q.Status)
+	}
+	if !strings.Contains(q.QuestionTextHTML, "math") || !strings.Contains(q.Options[0].TextHTML, "math") {
+		t.Errorf("content was not rendered: %q, %q", q.QuestionTextHTML, q.Options[0].TextHTML)
+	}
+
+	if _, err := app.store.Questions.GetByID(t.Context(), q.ID); err != nil {
+		t.Errorf("question was not stored: %v", err)
+	}
+}
+
+func TestCreateQuestionValidation(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		fields map[string]string
+	}{
+		{
+			name: "missing fields",
+			body: `{}`,
+			fields: map[string]string{
+				"category":      "required",
+				"question_text": "required",
+				"options":       "required",
+				"explanation":   "required",
+			},
+		},
+		{
+			name: "invalid values",
+			body: `{"category":"ABC","question_text":"pendek","question_image_url":"bukan url","options":[{"code":"AB","text":"x","score":9},{"code":"B","text":"y"}],"explanation":"x"}`,
+			fields: map[string]string{
+				"category":           "oneof",
+				"question_text":      "min",
+				"question_image_url": "url",
+				"options[0].code":    "len",
+				"options[0].score":   "max",
+			},
+		},
+		{
+			name: "unsupported markup",
+			body: `{"category":"TIU","question_text":"# Judul soal yang panjang","options":[{"code":"A","text":"$\\foo$"},{"code":"B","text":"b"}],"explanation":"<b>x</b>"}`,
+			fields: map[string]string{
+				"question_text":   "heading",
+				"options[0].text": "tex_unsupported_command",
+				"explanation":     "html",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestApplication(t).mount()
+
+			rec := doRequest(t, h, http.MethodPost, "/questions", tt.body)
+			res := assertError(t, rec, http.StatusUnprocessableEntity, codeValidationFailed)
+
+			if len(res.Error.Fields) != len(tt.fields) {
+				t.Errorf("fields = %v, want %v", res.Error.Fields, tt.fields)
+			}
+			for field, code := range tt.fields {
+				got, ok := res.Error.Fields[field]
+				if !ok {
+					t.Errorf("missing error for field %q", field)
+					continue
+				}
+				if got.Code != code {
+					t.Errorf("field %q code = %q, want %q", field, got.Code, code)
+				}
+				if got.Message == "" {
+					t.Errorf("field %q has no message", field)
+				}
+			}
+		})
+	}
+}
+
+func TestCreateQuestionBadJSON(t *testing.T) {
+	h := newTestApplication(t).mount()
+
+	tests := []struct {
+		name           string
+		body           string
+		acceptLanguage string
+		message        string
+	}{
+		{"truncated", `{"category":`, "id", "body bukan JSON yang valid"},
+		{"empty body", "", "id", "body bukan JSON yang valid"},
+		{"wrong type", `{"options":"A"}`, "id", "field options memiliki tipe data yang salah"},
+		{"wrong nested type", `{"options":[{"score":"lima"}]}`, "en", "field options[0].score has the wrong type"},
+		{"unknown field", `{"kategori":"TIU"}`, "en", "unknown field kategori"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/questions", strings.NewReader(tt.body))
+			req.Header.Set("Accept-Language", tt.acceptLanguage)
+			rec := doRequestWith(t, h, req)
+
+			res := assertError(t, rec, http.StatusBadRequest, codeInvalidJSON)
+			if res.Error.Message != tt.message {
+				t.Errorf("message = %q, want %q", res.Error.Message, tt.message)
+			}
+		})
+	}
+}
+
+func TestGetQuestionsPagination(t *testing.T) {
+	app := newTestApplication(t)
+	for i := 1; i <= 25; i++ {
+		seedQuestion(t, app, "TIU", fmt.Sprintf("Soal nomor %02d", i), models.QuestionStatusPublished)
+	}
+	h := app.mount()
+
+	tests := []struct {
+		query     string
+		wantItems int
+		wantMeta  store.MetaData
+		wantFirst string
+	}{
+		{"", 20, store.MetaData{CurrentPage: 1, Limit: 20, TotalItems: 25, TotalPages: 2}, "Soal nomor 25"},
+		{"?limit=10", 10, store.MetaData{CurrentPage: 1, Limit: 10, TotalItems: 25, TotalPages: 3}, "Soal nomor 25"},
+		{"?limit=10&offset=10", 10, store.MetaData{CurrentPage: 2, Limit: 10, TotalItems: 25, TotalPages: 3}, "Soal nomor 15"},
+		{"?limit=10&offset=20", 5, store.MetaData{CurrentPage: 3, Limit: 10, TotalItems: 25, TotalPages: 3}, "Soal nomor 05"},
+		{"?limit=7&offset=3", 7, store.MetaData{CurrentPage: 1, Limit: 7, TotalItems: 25, TotalPages: 4}, "Soal nomor 22"},
+		{"?limit=10&offset=30", 0, store.MetaData{CurrentPage: 4, Limit: 10, TotalItems: 25, TotalPages: 3}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			rec := doRequest(t, h, http.MethodGet, "/questions"+tt.query, "")
+			assertStatus(t, rec, http.StatusOK)
+
+			res := decodeResponse(t, rec)
+			var questions []models.Question
+			decodeData(t, res, &questions)
+
+			if len(questions) != tt.wantItems {
+				t.Errorf("got %d questions, want %d", len(questions), tt.wantItems)
+			}
+			if res.Meta == nil || *res.Meta != tt.wantMeta {
+				t.Errorf("meta = %+v, want %+v", res.Meta, tt.wantMeta)
+			}
+			if tt.wantFirst != "" && len(questions) > 0 && questions[0].QuestionText != tt.wantFirst {
+				t.Errorf("first question = %q, want %q", questions[0].QuestionText, tt.wantFirst)
+			}
+		})
+	}
+}
+
+func TestGetQuestionsFilters(t *testing.T) {
+	app := newTestApplication(t)
+	seedQuestion(t, app, "TIU", "Deret angka berikutnya", models.QuestionStatusInReview)
+	seedQuestion(t, app, "TWK", "Pancasila sebagai dasar negara", models.QuestionStatusPublished)
+	seedQuestion(t, app, "TWK", "Sejarah pancasila", models.QuestionStatusInReview)
+	seedQuestion(t, app, "TWK", "Makna sila pancasila", "")
+	h := app.mount()
+
+	tests := []struct {
+		query string
+		want  int
+	}{
+		// Tanpa status hanya soal yang sudah tayang
+		{"", 1},
+		{"?search=PANCASILA", 1},
+		{"?status=all", 4},
+		{"?status=", 4},
+		{"?search=PANCASILA&status=all", 3},
+		{"?status=in_review", 2},
+		{"?search=pancasila&status=in_review", 1},
+		{"?status=draft", 1},
+		{"?status=published", 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			rec := doRequest(t, h, http.MethodGet, "/questions"+tt.query, "")
+			assertStatus(t, rec, http.StatusOK)
+
+			res := decodeResponse(t, rec)
+			var questions []models.Question
+			decodeData(t, res, &questions)
+
+			if len(questions) != tt.want || res.Meta.TotalItems != tt.want {
+				t.Errorf("got %d questions (total %d), want %d", len(questions), res.Meta.TotalItems, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetQuestionsInvalidQuery(t *testing.T) {
+	h := newTestApplication(t).mount()
+
+	tests := []struct {
+		query string
+		field string
+		code  string
+	}{
+		{"?limit=50", "limit", "lte"},
+		{"?limit=0", "limit", "gte"},
+		{"?offset=-1", "offset", "gte"},
+		{"?status=unknown", "status", "oneof"},
+		{"?search=" + strings.Repeat("a", 101), "search", "max"},
+		{"?limit=x&status=draft", "limit", "integer"},
+		{"?limit=x&status=bogus", "limit", "integer"},
+		{"?offset=x", "offset", "integer"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field+"_"+tt.code, func(t *testing.T) {
+			rec := doRequest(t, h, http.MethodGet, "/questions"+tt.query, "")
+			res := assertError(t, rec, http.StatusBadRequest, codeInvalidQuery)
+
+			if got := res.Error.Fields[tt.field].Code; got != tt.code {
+				t.Errorf("field %q code = %q, want %q", tt.field, got, tt.code)
+			}
+		})
+	}
+}
+
+func TestUpdateQuestion(t *testing.T) {
+	app := newTestApplication(t)
+	existing := seedQuestion(t, app, "TWK", "Soal lama yang akan diubah", models.QuestionStatusInReview)
+	h := app.mount()
+
+	rec := doRequest(t, h, http.MethodPut, fmt.Sprintf("/questions/%d", existing.ID), validQuestionJSON)
+	assertStatus(t, rec, http.StatusOK)
+
+	var q models.Question
+	decodeData(t, decodeResponse(t, rec), &q)
+	if q.Category != "TIU" || q.Status != models.QuestionStatusInReview {
+		t.Errorf("got category %q status %q, want TIU and unchanged status", q.Category, q.Status)
+	}
+
+	stored, err := app.store.Questions.GetByID(t.Context(), existing.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Category != "TIU" {
+		t.Errorf("stored category = %q, want TIU", stored.Category)
+	}
+}
+
+func TestUpdateQuestionErrors(t *testing.T) {
+	h := newTestApplication(t).mount()
+
+	tests := []struct {
+		name   string
+		target string
+		body   string
+		status int
+		code   string
+	}{
+		{"not found", "/questions/99", validQuestionJSON, http.StatusNotFound, codeNotFound},
+		{"invalid id", "/questions/abc", validQuestionJSON, http.StatusBadRequest, "invalid_id"},
+		{"zero id", "/questions/0", validQuestionJSON, http.StatusBadRequest, "invalid_id"},
+		{"validation", "/questions/1", `{}`, http.StatusUnprocessableEntity, codeValidationFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := doRequest(t, h, http.MethodPut, tt.target, tt.body)
+			assertError(t, rec, tt.status, tt.code)
+		})
+	}
+}
+
+func TestDeleteQuestion(t *testing.T) {
+	app := newTestApplication(t)
+	q := seedQuestion(t, app, "TKP", "Soal yang akan dihapus", "")
+	h := app.mount()
+
+	rec := doRequest(t, h, http.MethodDelete, fmt.Sprintf("/questions/%d", q.ID), "")
+	assertStatus(t, rec, http.StatusOK)
+
+	rec = doRequest(t, h, http.MethodDelete, fmt.Sprintf("/questions/%d", q.ID), "")
+	assertError(t, rec, http.StatusNotFound, codeNotFound)
+
+	rec = doRequest(t, h, http.MethodDelete, "/questions/abc", "")
+	assertError(t, rec, http.StatusBadRequest, "invalid_id")
+}
+
+func TestUpdateQuestionStatus(t *testing.T) {
+	tests := []struct {
+		name   string
+		from   string
+		body   string
+		status int
+		code   string
+	}{
+		{"draft to in_review", models.QuestionStatusDraft, `{"status":"in_review"}`, http.StatusOK, ""},
+		{"approved to published", models.QuestionStatusApproved, `{"status":"published"}`, http.StatusOK, ""},
+		{"draft to published", models.QuestionStatusDraft, `{"status":"published"}`, http.StatusConflict, "status_transition"},
+		{"unknown status", models.QuestionStatusDraft, `{"status":"archived"}`, http.StatusUnprocessableEntity, codeValidationFailed},
+		{"unknown field", models.QuestionStatusDraft, `{"state":"in_review"}`, http.StatusBadRequest, codeInvalidJSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := newTestApplication(t)
+			q := seedQuestion(t, app, "TIU", "Soal untuk uji status", "")
+			// Jalankan transisi yang valid sampai status awal yang diinginkan
+			for _, s := range []string{models.QuestionStatusInReview, models.QuestionStatusApproved} {
+				if q.Status == tt.from {
+					break
+				}
+				if err := app.store.Questions.UpdateStatus(t.Context(), q, s); err != nil {
+					t.Fatal(err)
+				}
+			}
+			h := app.mount()
+
+			rec := doRequest(t, h, http.MethodPatch, fmt.Sprintf("/questions/%d/status", q.ID), tt.body)
+			if tt.code != "" {
+				assertError(t, rec, tt.status, tt.code)
+				return
+			}
+			assertStatus(t, rec, tt.status)
+
+			var got models.Question
+			decodeData(t, decodeResponse(t, rec), &got)
+			if !strings.Contains(tt.body, got.Status) {
+				t.Errorf("status = %q, want the one from %s", got.Status, tt.body)
+			}
+		})
+	}
+}
+
+func TestUpdateQuestionStatusNotFound(t *testing.T) {
+	h := newTestApplication(t).mount()
+
+	rec := doRequest(t, h, http.MethodPatch, "/questions/42/status", `{"status":"in_review"}`)
+	assertError(t, rec, http.StatusNotFound, codeNotFound)
+}
+
+// failingQuestionStore meniru database yang sedang bermasalah saat mengambil daftar soal
+type failingQuestionStore struct {
+	*store.MemoryQuestionStore
+}
+
+func (failingQuestionStore) GetQuestions(ctx context.Context, qq store.PaginatedQuestionQuery) ([]models.Question, store.MetaData, error) {
+	return nil, store.MetaData{}, errors.New("connection refused")
+}
+
+func TestGetQuestionsStoreFailure(t *testing.T) {
+	app := newTestApplication(t)
+	app.store.Questions = failingQuestionStore{app.store.Questions.(*store.MemoryQuestionStore)}
+	h := app.mount()
+
+	rec := doRequest(t, h, http.MethodGet, "/questions", "")
+	res := assertError(t, rec, http.StatusInternalServerError, codeInternal)
+
+	// Hanya satu body JSON yang ditulis, tanpa envelope sukses di belakangnya
+	if res.Data != nil {
+		t.Errorf("error response also contains data: %s", rec.Body.String())
+	}
+	if strings.Count(rec.Body.String(), "\n") != 1 {
+		t.Errorf("body has more than one JSON value: %q", rec.Body.String())
+	}
+}
+
+func TestUpdateQuestionRequiresReview(t *testing.T) {
+	tests := []struct {
+		from       string
+		status     int
+		code       string
+		wantStatus string
+	}{
+		{models.QuestionStatusDraft, http.StatusOK, "", models.QuestionStatusDraft},
+		{models.QuestionStatusInReview, http.StatusOK, "", models.QuestionStatusInReview},
+		{models.QuestionStatusApproved, http.StatusOK, "", models.QuestionStatusDraft},
+		{models.QuestionStatusPublished, http.StatusConflict, "status_transition", models.QuestionStatusPublished},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.from, func(t *testing.T) {
+			app := newTestApplication(t)
+			q := seedQuestion(t, app, "TWK", "Soal lama yang akan diubah", "")
+			for _, s := range []string{models.QuestionStatusInReview, models.QuestionStatusApproved, models.QuestionStatusPublished} {
+				if q.Status == tt.from {
+					break
+				}
+				if err := app.store.Questions.UpdateStatus(t.Context(), q, s); err != nil {
+					t.Fatal(err)
+				}
+			}
+			h := app.mount()
+
+			rec := doRequest(t, h, http.MethodPut, fmt.Sprintf("/questions/%d", q.ID), validQuestionJSON)
+			if tt.code != "" {
+				assertError(t, rec, tt.status, tt.code)
+			} else {
+				assertStatus(t, rec, tt.status)
+			}
+
+			stored, err := app.store.Questions.GetByID(t.Context(), q.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if stored.Status != tt.wantStatus {
+				t.Errorf("status = %q, want %q", stored.Status, tt.wantStatus)
+			}
+			// Isi soal published tidak boleh berubah
+			if tt.code != "" && stored.Category != "TWK" {
+				t.Errorf("content of a rejected edit was saved: category %q", stored.Category)
+			}
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ReyviRahman/to-backend/internal/models"
+	"github.com/ReyviRahman/to-backend/internal/store"
+)
+
+type CreateReportPayload struct {
+	// Asal laporan: saat latihan atau saat melihat hasil tryout
+	Source      string `json:"source" validate:"required,oneof=practice result"`
+	Reason      string `json:"reason" validate:"required,oneof=wrong_key typo unclear_image outdated_regulation"`
+	Description string `json:"description" validate:"max=1000"`
+}
+
+type UpdateReportPayload struct {
+	Status         string `json:"status" validate:"required,oneof=open in_progress resolved rejected"`
+	ResolutionNote string `json:"resolution_note" validate:"max=2000"`
+}
+
+func (app *application) createReportHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := readIDParam(r)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	var payload CreateReportPayload
+	if err := readJSON(w, r, &payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(payload); err != nil {
+		app.validationErrorResponse(w, r, err)
+		return
+	}
+
+	ctx := r.Context()
+	question, err := app.store.Questions.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			app.notFoundResponse(w, r, err)
+			return
+		}
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	// Siswa hanya melihat soal yang sudah tayang
+	if question.Status != models.QuestionStatusPublished {
+		app.conflictResponse(w, r, newLocalizedError("error.question_not_published"))
+		return
+	}
+
+	// Regulasi hanya relevan untuk materi TWK
+	if payload.Reason == models.ReportReasonOutdatedRegulation && question.Category != "TWK" {
+		app.fieldErrorResponse(w, r, map[string]error{"reason": newLocalizedError("error.report_reason_twk")})
+		return
+	}
+
+	report := &models.QuestionReport{
+		QuestionID:  id,
+		Source:      payload.Source,
+		Reason:      payload.Reason,
+		Description: payload.Description,
+	}
+
+	if err := app.store.Reports.Create(ctx, report); err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+	reportsSubmittedTotal.WithLabelValues(report.Reason).Inc()
+
+	if err := app.jsonResponse(w, r, http.StatusCreated, "report.created", report); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+func (app *application) getReportsHandler(w http.ResponseWriter, r *http.Request) {
+	rq := store.PaginatedReportQuery{
+		Limit:  20,
+		Offset: 0,
+		Status: models.ReportStatusOpen,
+	}
+
+	rq, err := rq.Parse(r)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(rq); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	ctx := r.Context()
+
+	reports, meta, err := app.store.Reports.GetReports(ctx, rq)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := app.jsonResponse(w, r, http.StatusOK, "success.fetched", reports, meta); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+func (app *application) updateReportHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := readIDParam(r)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	var payload UpdateReportPayload
+	if err := readJSON(w, r, &payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(payload); err != nil {
+		app.validationErrorResponse(w, r, err)
+		return
+	}
+
+	closed := payload.Status == models.ReportStatusResolved || payload.Status == models.ReportStatusRejected
+	if closed && payload.ResolutionNote == "" {
+		app.fieldErrorResponse(w, r, map[string]error{"resolution_note": newLocalizedError("error.resolution_note_required")})
+		return
+	}
+
+	ctx := r.Context()
+	report, err := app.store.Reports.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			app.notFoundResponse(w, r, err)
+			return
+		}
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	report.Status = payload.Status
+	report.ResolutionNote = payload.ResolutionNote
+
+	if err := app.store.Reports.Update(ctx, report); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			app.notFoundResponse(w, r, err)
+			return
+		}
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := app.jsonResponse(w, r, http.StatusOK, "report.updated", report); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
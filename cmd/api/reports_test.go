@@ -0,0 +1,155 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/ReyviRahman/to-backend/internal/models"
+)
+
+func TestCreateReport(t *testing.T) {
+	app := newTestApplication(t)
+	tiu := seedQuestion(t, app, "TIU", "Soal hitungan TIU", models.QuestionStatusPublished)
+	twk := seedQuestion(t, app, "TWK", "Soal regulasi TWK", models.QuestionStatusPublished)
+	draft := seedQuestion(t, app, "TIU", "Soal yang belum tayang", models.QuestionStatusInReview)
+	h := app.mount()
+
+	tests := []struct {
+		name   string
+		id     int64
+		body   string
+		status int
+		code   string
+	}{
+		{"wrong key", tiu.ID, `{"source":"practice","reason":"wrong_key","description":"kunci seharusnya B"}`, http.StatusCreated, ""},
+		{"outdated regulation on TWK", twk.ID, `{"source":"result","reason":"outdated_regulation"}`, http.StatusCreated, ""},
+		{"outdated regulation on TIU", tiu.ID, `{"source":"result","reason":"outdated_regulation"}`, http.StatusUnprocessableEntity, codeValidationFailed},
+		{"unknown reason", tiu.ID, `{"source":"result","reason":"boring"}`, http.StatusUnprocessableEntity, codeValidationFailed},
+		{"unknown question", 99, `{"source":"result","reason":"typo"}`, http.StatusNotFound, codeNotFound},
+		{"unpublished question", draft.ID, `{"source":"result","reason":"typo"}`, http.StatusConflict, "question_not_published"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := doRequest(t, h, http.MethodPost, fmt.Sprintf("/questions/%d/reports", tt.id), tt.body)
+			if tt.code != "" {
+				assertError(t, rec, tt.status, tt.code)
+				return
+			}
+			assertStatus(t, rec, tt.status)
+
+			var report models.QuestionReport
+			decodeData(t, decodeResponse(t, rec), &report)
+			if report.Status != models.ReportStatusOpen || report.QuestionID != tt.id {
+				t.Errorf("report = %+v, want an open report on question %d", report, tt.id)
+			}
+		})
+	}
+	// Aturan per field dilaporkan di fields, sama seperti error validasi lain
+	rec := doRequest(t, h, http.MethodPost, fmt.Sprintf("/questions/%d/reports", tiu.ID), `{"source":"result","reason":"outdated_regulation"}`)
+	res := assertError(t, rec, http.StatusUnprocessableEntity, codeValidationFailed)
+	if got := res.Error.Fields["reason"]; got.Code != "report_reason_twk" || got.Message == "" {
+		t.Errorf("reason field error = %+v, want code report_reason_twk", got)
+	}
+}
+
+func TestGetReports(t *testing.T) {
+	app := newTestApplication(t)
+	q := seedQuestion(t, app, "TIU", "Soal yang sering dilaporkan", "")
+	for i := 0; i < 3; i++ {
+		report := &models.QuestionReport{QuestionID: q.ID, Source: "practice", Reason: "typo"}
+		if err := app.store.Reports.Create(t.Context(), report); err != nil {
+			t.Fatal(err)
+		}
+	}
+	resolved := &models.QuestionReport{ID: 3, Status: models.ReportStatusResolved, ResolutionNote: "sudah diperbaiki"}
+	if err := app.store.Reports.Update(t.Context(), resolved); err != nil {
+		t.Fatal(err)
+	}
+	h := app.mount()
+
+	tests := []struct {
+		query string
+		want  []int64
+		total int
+	}{
+		{"", []int64{1, 2}, 2},
+		{"?status=resolved", []int64{3}, 1},
+		{"?status=all", []int64{1, 2, 3}, 3},
+		{"?status=", []int64{1, 2, 3}, 3},
+		{"?status=all&limit=1&offset=2", []int64{3}, 3},
+		{"?limit=1&offset=1", []int64{2}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			rec := doRequest(t, h, http.MethodGet, "/reports"+tt.query, "")
+			assertStatus(t, rec, http.StatusOK)
+
+			res := decodeResponse(t, rec)
+			var reports []models.QuestionReport
+			decodeData(t, res, &reports)
+
+			var got []int64
+			for _, rp := range reports {
+				got = append(got, rp.ID)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) || res.Meta.TotalItems != tt.total {
+				t.Errorf("got ids %v (total %d), want %v (total %d)", got, res.Meta.TotalItems, tt.want, tt.total)
+			}
+		})
+	}
+
+	for _, query := range []string{"?status=closed", "?offset=x&status=resolved"} {
+		rec := doRequest(t, h, http.MethodGet, "/reports"+query, "")
+		assertError(t, rec, http.StatusBadRequest, codeInvalidQuery)
+	}
+
+	// Limit yang bukan angka tidak boleh diam-diam menghapus filter status
+	rec := doRequest(t, h, http.MethodGet, "/reports?limit=x&status=resolved", "")
+	res := assertError(t, rec, http.StatusBadRequest, codeInvalidQuery)
+	if got := res.Error.Fields["limit"]; got.Code != "integer" || got.Message == "" {
+		t.Errorf("limit field error = %+v, want code integer", got)
+	}
+}
+
+func TestUpdateReport(t *testing.T) {
+	app := newTestApplication(t)
+	q := seedQuestion(t, app, "TIU", "Soal yang dilaporkan siswa", "")
+	report := &models.QuestionReport{QuestionID: q.ID, Source: "practice", Reason: "wrong_key"}
+	if err := app.store.Reports.Create(t.Context(), report); err != nil {
+		t.Fatal(err)
+	}
+	h := app.mount()
+	target := fmt.Sprintf("/reports/%d", report.ID)
+
+	rec := doRequest(t, h, http.MethodPatch, target, `{"status":"resolved"}`)
+	res := assertError(t, rec, http.StatusUnprocessableEntity, codeValidationFailed)
+	if got := res.Error.Fields["resolution_note"]; got.Code != "resolution_note_required" || got.Message == "" {
+		t.Errorf("resolution_note field error = %+v, want code resolution_note_required", got)
+	}
+
+	rec = doRequest(t, h, http.MethodPatch, target, `{"status":"resolved","resolution_note":"kunci diperbaiki"}`)
+	assertStatus(t, rec, http.StatusOK)
+
+	var updated models.QuestionReport
+	decodeData(t, decodeResponse(t, rec), &updated)
+	if updated.Status != models.ReportStatusResolved || updated.ResolvedAt == nil {
+		t.Errorf("report = %+v, want resolved with resolved_at", updated)
+	}
+
+	// Membuka ulang laporan mengosongkan resolved_at
+	rec = doRequest(t, h, http.MethodPatch, target, `{"status":"in_progress"}`)
+	assertStatus(t, rec, http.StatusOK)
+	decodeData(t, decodeResponse(t, rec), &updated)
+	if updated.ResolvedAt != nil {
+		t.Errorf("resolved_at = %v, want null after reopening", updated.ResolvedAt)
+	}
+
+	rec = doRequest(t, h, http.MethodPatch, "/reports/99", `{"status":"in_progress"}`)
+	assertError(t, rec, http.StatusNotFound, codeNotFound)
+
+	rec = doRequest(t, h, http.MethodPatch, target, `{"status":"done"}`)
+	assertError(t, rec, http.StatusUnprocessableEntity, codeValidationFailed)
+}
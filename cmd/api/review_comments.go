@@ -0,0 +1,87 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/ReyviRahman/to-backend/internal/models"
+	"github.com/ReyviRahman/to-backend/internal/store"
+)
+
+type CreateReviewCommentPayload struct {
+	// Nama field soal yang dikomentari, sama dengan nama field di JSON
+	Field string `json:"field" validate:"required,oneof=category question_text question_image_url options explanation"`
+	Body  string `json:"body" validate:"required,max=2000"`
+}
+
+func (app *application) createReviewCommentHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := readIDParam(r)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	var payload CreateReviewCommentPayload
+	if err := readJSON(w, r, &payload); err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	if err := Validate.Struct(payload); err != nil {
+		app.validationErrorResponse(w, r, err)
+		return
+	}
+
+	ctx := r.Context()
+	if _, err := app.store.Questions.GetByID(ctx, id); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			app.notFoundResponse(w, r, err)
+			return
+		}
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	comment := &models.ReviewComment{
+		QuestionID: id,
+		Field:      payload.Field,
+		Body:       payload.Body,
+	}
+
+	if err := app.store.ReviewComments.Create(ctx, comment); err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := app.jsonResponse(w, r, http.StatusCreated, "review_comment.created", comment); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
+
+func (app *application) getReviewCommentsHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := readIDParam(r)
+	if err != nil {
+		app.badRequestResponse(w, r, err)
+		return
+	}
+
+	ctx := r.Context()
+	if _, err := app.store.Questions.GetByID(ctx, id); err != nil {
+		if errors.Is(err, store.ErrNotFound) {
+			app.notFoundResponse(w, r, err)
+			return
+		}
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	comments, err := app.store.ReviewComments.GetByQuestionID(ctx, id)
+	if err != nil {
+		app.internalServerError(w, r, err)
+		return
+	}
+
+	if err := app.jsonResponse(w, r, http.StatusOK, "success.fetched", comments); err != nil {
+		app.internalServerError(w, r, err)
+	}
+}
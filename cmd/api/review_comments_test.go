@@ -0,0 +1,72 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/ReyviRahman/to-backend/internal/models"
+)
+
+func TestReviewComments(t *testing.T) {
+	app := newTestApplication(t)
+	q := seedQuestion(t, app, "TIU", "Soal yang sedang direview", models.QuestionStatusInReview)
+	h := app.mount()
+	target := fmt.Sprintf("/questions/%d/review-comments", q.ID)
+
+	for _, field := range []string{"question_text", "options"} {
+		rec := doRequest(t, h, http.MethodPost, target, `{"field":"`+field+`","body":"perlu diperbaiki"}`)
+		assertStatus(t, rec, http.StatusCreated)
+	}
+
+	rec := doRequest(t, h, http.MethodGet, target, "")
+	assertStatus(t, rec, http.StatusOK)
+
+	var comments []models.ReviewComment
+	decodeData(t, decodeResponse(t, rec), &comments)
+	if len(comments) != 2 || comments[0].Field != "question_text" || comments[1].Field != "options" {
+		t.Errorf("comments = %+v, want question_text then options", comments)
+	}
+}
+
+func TestReviewCommentsEmptyList(t *testing.T) {
+	app := newTestApplication(t)
+	q := seedQuestion(t, app, "TIU", "Soal tanpa komentar review", "")
+	h := app.mount()
+
+	rec := doRequest(t, h, http.MethodGet, fmt.Sprintf("/questions/%d/review-comments", q.ID), "")
+	assertStatus(t, rec, http.StatusOK)
+
+	if got := string(decodeResponse(t, rec).Data); got != "[]" {
+		t.Errorf("data = %s, want []", got)
+	}
+}
+
+func TestReviewCommentsErrors(t *testing.T) {
+	app := newTestApplication(t)
+	q := seedQuestion(t, app, "TIU", "Soal yang sedang direview", "")
+	h := app.mount()
+	target := fmt.Sprintf("/questions/%d/review-comments", q.ID)
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		status int
+		code   string
+	}{
+		{"list unknown question", http.MethodGet, "/questions/99/review-comments", "", http.StatusNotFound, codeNotFound},
+		{"list invalid id", http.MethodGet, "/questions/x/review-comments", "", http.StatusBadRequest, "invalid_id"},
+		{"create unknown question", http.MethodPost, "/questions/99/review-comments", `{"field":"options","body":"x"}`, http.StatusNotFound, codeNotFound},
+		{"create unknown field", http.MethodPost, target, `{"field":"status","body":"x"}`, http.StatusUnprocessableEntity, codeValidationFailed},
+		{"create empty body", http.MethodPost, target, "", http.StatusBadRequest, codeInvalidJSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := doRequest(t, h, tt.method, tt.target, tt.body)
+			assertError(t, rec, tt.status, tt.code)
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ReyviRahman/to-backend/internal/config"
+	"github.com/ReyviRahman/to-backend/internal/models"
+	"github.com/ReyviRahman/to-backend/internal/ratelimit"
+	"github.com/ReyviRahman/to-backend/internal/store"
+	"go.uber.org/zap"
+)
+
+// newTestApplication membuat application dengan store memori dan rate limiter
+// nonaktif. Test yang butuh rate limiter bisa mengubah app.config sebelum mount().
+func newTestApplication(t *testing.T) *application {
+	t.Helper()
+
+	limiter := ratelimit.NewMemoryLimiter(time.Minute)
+	t.Cleanup(limiter.Stop)
+
+	return &application{
+		config: config.Config{
+			RateLimit: config.RateLimitConfig{
+				Enabled: false,
+				Global:  config.RateLimitRule{Requests: 100, Window: time.Minute},
+				Strict:  config.RateLimitRule{Requests: 100, Window: time.Minute},
+			},
+		},
+		store:       store.NewMemoryStorage(),
+		logger:      zap.NewNop().Sugar(),
+		rateLimiter: limiter,
+	}
+}
+
+// testResponse adalah gabungan envelope sukses dan error supaya
+// satu helper bisa dipakai untuk semua response JSON
+type testResponse struct {
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+	Meta    *store.MetaData `json:"meta"`
+	Error   *apiError       `json:"error"`
+}
+
+func doRequest(t *testing.T, h http.Handler, method, target, body string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	var reader io.Reader
+	if body != "" {
+		reader = strings.NewReader(body)
+	}
+
+	req := httptest.NewRequest(method, target, reader)
+	req.Header.Set("Accept-Language", "en")
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return doRequestWith(t, h, req)
+}
+
+// doRequestWith dipakai jika test perlu mengatur header sendiri
+func doRequestWith(t *testing.T, h http.Handler, req *http.Request) *httptest.ResponseRecorder {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func decodeResponse(t *testing.T, rec *httptest.ResponseRecorder) testResponse {
+	t.Helper()
+
+	var res testResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+		t.Fatalf("decoding response %q: %v", rec.Body.String(), err)
+	}
+
+	return res
+}
+
+// decodeData membaca field data dari envelope sukses ke dst
+func decodeData(t *testing.T, res testResponse, dst any) {
+	t.Helper()
+
+	if err := json.Unmarshal(res.Data, dst); err != nil {
+		t.Fatalf("decoding data %s: %v", res.Data, err)
+	}
+}
+
+func assertStatus(t *testing.T, rec *httptest.ResponseRecorder, want int) {
+	t.Helper()
+
+	if rec.Code != want {
+		t.Fatalf("status = %d, want %d; body: %s", rec.Code, want, rec.Body.String())
+	}
+}
+
+// assertError memastikan status dan kode error di envelope error
+func assertError(t *testing.T, rec *httptest.ResponseRecorder, status int, code string) testResponse {
+	t.Helper()
+
+	assertStatus(t, rec, status)
+
+	res := decodeResponse(t, rec)
+	if res.Error == nil {
+		t.Fatalf("response has no error envelope: %s", rec.Body.String())
+	}
+	if res.Error.Code != code {
+		t.Fatalf("error code = %q, want %q; body: %s", res.Error.Code, code, rec.Body.String())
+	}
+	if res.Error.Message == "" {
+		t.Errorf("error message is empty")
+	}
+	if res.Error.RequestID == "" {
+		t.Errorf("error request_id is empty")
+	}
+
+	return res
+}
+
+const validQuestionJSON = `{
+	"category": "TIU",
+	"question_text": "Berapakah hasil dari $\\frac{1}{2} + \\frac{1}{4}$?",
+	"options": [
+		{"code": "A", "text": "$\\frac{3}{4}$", "score": 5},
+		{"code": "B", "text": "$\\frac{2}{6}$", "score": 0}
+	],
+	"explanation": "Samakan penyebutnya terlebih dahulu."
+}`
+
+// seedQuestion menyimpan soal langsung lewat store dengan status tertentu
+func seedQuestion(t *testing.T, app *application, category, text, status string) *models.Question {
+	t.Helper()
+
+	ctx := t.Context()
+	q := &models.Question{
+		Category:     category,
+		QuestionText: text,
+		Options: models.QuestionOptions{
+			{Code: "A", Text: "benar", Score: 5},
+			{Code: "B", Text: "salah", Score: 0},
+		},
+		Explanation: "penjelasan",
+	}
+	if err := app.store.Questions.Create(ctx, q); err != nil {
+		t.Fatalf("seeding question: %v", err)
+	}
+
+	if status != "" && status != q.Status {
+		if err := app.store.Questions.UpdateStatus(ctx, q, status); err != nil {
+			t.Fatalf("seeding question status: %v", err)
+		}
+	}
+
+	return q
+}
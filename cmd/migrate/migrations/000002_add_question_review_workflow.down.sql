@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS question_review_comments;
+DROP INDEX IF EXISTS idx_questions_status;
+ALTER TABLE questions DROP COLUMN IF EXISTS status;
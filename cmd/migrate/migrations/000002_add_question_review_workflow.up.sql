@@ -0,0 +1,15 @@
+-- Soal yang sudah ada sebelumnya dianggap sudah tayang
+ALTER TABLE questions ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'published';
+ALTER TABLE questions ALTER COLUMN status SET DEFAULT 'draft';
+
+CREATE INDEX IF NOT EXISTS idx_questions_status ON questions (status);
+
+CREATE TABLE IF NOT EXISTS question_review_comments (
+  id BIGSERIAL PRIMARY KEY,
+  question_id BIGINT NOT NULL REFERENCES questions (id) ON DELETE CASCADE,
+  field VARCHAR(30) NOT NULL,
+  body TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_question_review_comments_question_id ON question_review_comments (question_id);
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS question_reports (
+  id BIGSERIAL PRIMARY KEY,
+  question_id BIGINT NOT NULL REFERENCES questions (id) ON DELETE CASCADE,
+  source VARCHAR(20) NOT NULL,
+  reason VARCHAR(30) NOT NULL,
+  description TEXT NOT NULL DEFAULT '',
+  status VARCHAR(20) NOT NULL DEFAULT 'open',
+  resolution_note TEXT NOT NULL DEFAULT '',
+  resolved_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_question_reports_status ON question_reports (status);
+CREATE INDEX IF NOT EXISTS idx_question_reports_question_id ON question_reports (question_id);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS question_comments (
+  id BIGSERIAL PRIMARY KEY,
+  question_id BIGINT NOT NULL REFERENCES questions (id) ON DELETE CASCADE,
+  parent_id BIGINT REFERENCES question_comments (id) ON DELETE CASCADE,
+  body TEXT NOT NULL,
+  is_official BOOLEAN NOT NULL DEFAULT FALSE,
+  is_hidden BOOLEAN NOT NULL DEFAULT FALSE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_question_comments_question_id ON question_comments (question_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_question_comments_parent_id ON question_comments (parent_id);
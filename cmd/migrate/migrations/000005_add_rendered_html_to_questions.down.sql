@@ -0,0 +1,7 @@
+UPDATE questions SET options = (
+  SELECT COALESCE(jsonb_agg(opt - 'text_html' ORDER BY ord), '[]'::jsonb)
+  FROM jsonb_array_elements(options) WITH ORDINALITY AS t(opt, ord)
+);
+
+ALTER TABLE questions DROP COLUMN IF EXISTS explanation_html;
+ALTER TABLE questions DROP COLUMN IF EXISTS question_text_html;
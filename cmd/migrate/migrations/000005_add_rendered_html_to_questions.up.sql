@@ -0,0 +1,19 @@
+ALTER TABLE questions ADD COLUMN IF NOT EXISTS question_text_html TEXT NOT NULL DEFAULT '';
+ALTER TABLE questions ADD COLUMN IF NOT EXISTS explanation_html TEXT NOT NULL DEFAULT '';
+
+-- Soal lama masih berupa teks biasa, jadi cukup di-escape lalu dibungkus <p>
+UPDATE questions SET
+  question_text_html = '<p>' || replace(replace(replace(question_text, '&', '&amp;'), '<', '&lt;'), '>', '&gt;') || '</p>',
+  explanation_html = CASE
+    WHEN COALESCE(explanation, '') = '' THEN ''
+    ELSE '<p>' || replace(replace(replace(explanation, '&', '&amp;'), '<', '&lt;'), '>', '&gt;') || '</p>'
+  END,
+  options = (
+    SELECT COALESCE(jsonb_agg(
+      opt || jsonb_build_object(
+        'text_html',
+        '<p>' || replace(replace(replace(opt->>'text', '&', '&amp;'), '<', '&lt;'), '>', '&gt;') || '</p>'
+      ) ORDER BY ord
+    ), '[]'::jsonb)
+    FROM jsonb_array_elements(options) WITH ORDINALITY AS t(opt, ord)
+  );
@@ -0,0 +1 @@
+ALTER TABLE question_comments DROP COLUMN IF EXISTS body_html;
@@ -0,0 +1,5 @@
+ALTER TABLE question_comments ADD COLUMN IF NOT EXISTS body_html TEXT NOT NULL DEFAULT '';
+
+-- Komentar lama disimpan tanpa render, jadi cukup di-escape lalu dibungkus <p>
+UPDATE question_comments SET
+  body_html = '<p>' || replace(replace(replace(body, '&', '&amp;'), '<', '&lt;'), '>', '&gt;') || '</p>';
@@ -0,0 +1,291 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// Config dibaca dengan urutan prioritas (yang belakang menimpa yang depan):
+// nilai default, file YAML (CONFIG_FILE), lalu environment variable.
+// File .env bersifat opsional dan hanya mengisi env yang belum ada.
+type Config struct {
+	Addr      string          `yaml:"addr" env:"ADDR" validate:"required"`
+	DB        DBConfig        `yaml:"db"`
+	RateLimit RateLimitConfig `yaml:"rate_limit"`
+	Shutdown  ShutdownConfig  `yaml:"shutdown"`
+	Tracing   TracingConfig   `yaml:"tracing"`
+}
+
+type DBConfig struct {
+	DSN          string        `yaml:"dsn" env:"DB_DSN" validate:"required" secret:"true"`
+	MaxOpenConns int           `yaml:"max_open_conns" env:"DB_MAX_OPEN_CONNS" validate:"gte=1"`
+	MaxIdleConns int           `yaml:"max_idle_conns" env:"DB_MAX_IDLE_CONNS" validate:"gte=0,ltefield=MaxOpenConns"`
+	MaxIdleTime  time.Duration `yaml:"max_idle_time" env:"DB_MAX_IDLE_TIME" validate:"gt=0s"`
+}
+
+type RateLimitConfig struct {
+	Enabled bool          `yaml:"enabled" env:"RATE_LIMIT_ENABLED"`
+	Global  RateLimitRule `yaml:"global" env:"RATE_LIMIT_GLOBAL"`
+	// Strict dipakai untuk route yang rawan spam, misal kirim laporan dan komentar
+	Strict RateLimitRule `yaml:"strict" env:"RATE_LIMIT_STRICT"`
+}
+
+type RateLimitRule struct {
+	Requests int           `yaml:"requests" env:"REQUESTS" validate:"gte=1"`
+	Window   time.Duration `yaml:"window" env:"WINDOW" validate:"gt=0s"`
+}
+
+type ShutdownConfig struct {
+	Timeout    time.Duration `yaml:"timeout" env:"SHUTDOWN_TIMEOUT" validate:"gt=0s"`
+	DrainDelay time.Duration `yaml:"drain_delay" env:"SHUTDOWN_DRAIN_DELAY" validate:"gte=0s"`
+}
+
+type TracingConfig struct {
+	Exporter string `yaml:"exporter" env:"OTEL_TRACES_EXPORTER" validate:"oneof=none stdout otlp"`
+}
+
+func defaults() Config {
+	return Config{
+		Addr: ":8080",
+		DB: DBConfig{
+			MaxOpenConns: 30,
+			MaxIdleConns: 30,
+			MaxIdleTime:  15 * time.Minute,
+		},
+		RateLimit: RateLimitConfig{
+			Enabled: true,
+			Global:  RateLimitRule{Requests: 120, Window: time.Minute},
+			Strict:  RateLimitRule{Requests: 10, Window: time.Minute},
+		},
+		Shutdown: ShutdownConfig{
+			Timeout: 30 * time.Second,
+		},
+		Tracing: TracingConfig{
+			Exporter: "none",
+		},
+	}
+}
+
+// Load membaca konfigurasi dan mengembalikan semua masalah sekaligus
+// (digabung dengan errors.Join), bukan berhenti di masalah pertama.
+func Load() (Config, error) {
+	cfg := defaults()
+
+	// .env hanya untuk development, di container env sudah diisi langsung
+	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return cfg, fmt.Errorf("gagal membaca .env: %w", err)
+	}
+
+	if path := os.Getenv("CONFIG_FILE"); path != "" {
+		f, err := os.Open(path)
+		if err != nil {
+			return cfg, fmt.Errorf("gagal membaca CONFIG_FILE: %w", err)
+		}
+		defer f.Close()
+
+		// KnownFields supaya salah ketik key di file YAML tidak diam-diam diabaikan
+		dec := yaml.NewDecoder(f)
+		dec.KnownFields(true)
+		if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+			return cfg, fmt.Errorf("gagal membaca %s: %w", path, err)
+		}
+	}
+
+	var errs []error
+	errs = append(errs, applyEnv(reflect.ValueOf(&cfg).Elem(), "")...)
+	errs = append(errs, validate(cfg)...)
+
+	return cfg, errors.Join(errs...)
+}
+
+// applyEnv mengisi field yang punya tag env. Untuk struct bertingkat,
+// tag env milik parent menjadi prefix (misal RATE_LIMIT_GLOBAL_REQUESTS).
+func applyEnv(v reflect.Value, prefix string) []error {
+	var errs []error
+
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		fv := v.Field(i)
+
+		name := field.Tag.Get("env")
+		if prefix != "" && name != "" {
+			name = prefix + "_" + name
+		}
+
+		if field.Type.Kind() == reflect.Struct {
+			errs = append(errs, applyEnv(fv, name)...)
+			continue
+		}
+
+		if name == "" {
+			continue
+		}
+
+		raw, ok := os.LookupEnv(name)
+		if !ok || raw == "" {
+			continue
+		}
+
+		if err := setValue(fv, raw); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w (nilai saat ini: %q)", name, err, raw))
+		}
+	}
+
+	return errs
+}
+
+func setValue(fv reflect.Value, raw string) error {
+	switch {
+	case fv.Type() == reflect.TypeOf(time.Duration(0)):
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return errors.New("harus berupa durasi (misal: 30s, 15m)")
+		}
+		fv.SetInt(int64(d))
+	case fv.Kind() == reflect.Int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return errors.New("harus berupa angka (integer)")
+		}
+		fv.SetInt(int64(n))
+	case fv.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return errors.New("harus berupa true atau false")
+		}
+		fv.SetBool(b)
+	case fv.Kind() == reflect.String:
+		fv.SetString(raw)
+	default:
+		return fmt.Errorf("tipe %s belum didukung", fv.Type())
+	}
+
+	return nil
+}
+
+func validate(cfg Config) []error {
+	v := validator.New(validator.WithRequiredStructEnabled())
+
+	err := v.Struct(cfg)
+	if err == nil {
+		return nil
+	}
+
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return []error{err}
+	}
+
+	errs := make([]error, 0, len(validationErrors))
+	for _, fe := range validationErrors {
+		rule := fe.Tag()
+		if fe.Param() != "" {
+			rule += "=" + fe.Param()
+		}
+		name, secret := fieldName(fe.StructNamespace())
+		if secret {
+			errs = append(errs, fmt.Errorf("%s: tidak valid, aturan %s", name, rule))
+			continue
+		}
+		errs = append(errs, fmt.Errorf("%s: tidak valid, aturan %s (nilai saat ini: %v)", name, rule, fe.Value()))
+	}
+
+	return errs
+}
+
+// fieldName mengubah namespace struct (Config.DB.MaxOpenConns) menjadi
+// nama yang dikenal user, yaitu nama env dan key YAML-nya
+func fieldName(namespace string) (name string, secret bool) {
+	t := reflect.TypeOf(Config{})
+	var envParts, yamlParts []string
+
+	for _, part := range strings.Split(namespace, ".")[1:] {
+		field, ok := t.FieldByName(part)
+		if !ok {
+			return namespace, false
+		}
+		secret = field.Tag.Get("secret") == "true"
+		if env := field.Tag.Get("env"); env != "" {
+			envParts = append(envParts, env)
+		}
+		yamlParts = append(yamlParts, field.Tag.Get("yaml"))
+		t = field.Type
+	}
+
+	return fmt.Sprintf("%s (%s)", strings.Join(envParts, "_"), strings.Join(yamlParts, ".")), secret
+}
+
+// Redacted mengembalikan konfigurasi efektif dalam bentuk key YAML bertitik,
+// dengan nilai rahasia (tag secret) disamarkan, untuk dicetak saat startup
+func (c Config) Redacted() map[string]any {
+	out := make(map[string]any)
+	flatten(reflect.ValueOf(c), "", out)
+	return out
+}
+
+func flatten(v reflect.Value, prefix string, out map[string]any) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key := field.Tag.Get("yaml")
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		fv := v.Field(i)
+		switch {
+		case field.Type.Kind() == reflect.Struct:
+			flatten(fv, key, out)
+		case field.Tag.Get("secret") == "true":
+			out[key] = redact(fv.String())
+		case field.Type == reflect.TypeOf(time.Duration(0)):
+			out[key] = time.Duration(fv.Int()).String()
+		default:
+			out[key] = fv.Interface()
+		}
+	}
+}
+
+// keywordPassword mencocokkan password di DSN berbentuk keyword/value,
+// misal: host=db user=app password='rahasia' dbname=tryout
+var keywordPassword = regexp.MustCompile(`(?i)(\b\w*password\s*=\s*)('(?:[^'\\]|\\.)*'|\S*)`)
+
+// redact menyamarkan password di DSN, baik berbentuk URL (userinfo maupun
+// query ?password=) atau keyword/value. Nilai lain yang tidak dikenali
+// disamarkan seluruhnya.
+func redact(s string) string {
+	if s == "" {
+		return ""
+	}
+
+	if u, err := url.Parse(s); err == nil && u.Scheme != "" {
+		query := u.Query()
+		for key := range query {
+			if strings.Contains(strings.ToLower(key), "password") {
+				query.Set(key, "xxxxx")
+			}
+		}
+		u.RawQuery = query.Encode()
+		return u.Redacted()
+	}
+
+	if strings.Contains(s, "=") {
+		return keywordPassword.ReplaceAllString(s, "${1}xxxxx")
+	}
+
+	return "********"
+}
@@ -0,0 +1,253 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// setEnv mengosongkan semua env yang dibaca Load supaya test tidak
+// terpengaruh environment mesin, lalu mengisi env dari test
+func setEnv(t *testing.T, env map[string]string) {
+	t.Helper()
+
+	for _, name := range []string{
+		"CONFIG_FILE", "ADDR", "DB_DSN", "DB_MAX_OPEN_CONNS", "DB_MAX_IDLE_CONNS", "DB_MAX_IDLE_TIME",
+		"RATE_LIMIT_ENABLED", "RATE_LIMIT_GLOBAL_REQUESTS", "RATE_LIMIT_GLOBAL_WINDOW",
+		"RATE_LIMIT_STRICT_REQUESTS", "RATE_LIMIT_STRICT_WINDOW",
+		"SHUTDOWN_TIMEOUT", "SHUTDOWN_DRAIN_DELAY", "OTEL_TRACES_EXPORTER",
+	} {
+		t.Setenv(name, "")
+	}
+	for name, value := range env {
+		t.Setenv(name, value)
+	}
+}
+
+func writeYAML(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	yaml := `
+addr: ":9000"
+db:
+  dsn: postgres://yaml@localhost/tryout
+  max_open_conns: 50
+shutdown:
+  timeout: 45s
+rate_limit:
+  strict:
+    requests: 3
+`
+
+	tests := []struct {
+		name  string
+		yaml  bool
+		env   map[string]string
+		check func(t *testing.T, cfg Config)
+	}{
+		{
+			name: "defaults",
+			env:  map[string]string{"DB_DSN": "postgres://env@localhost/tryout"},
+			check: func(t *testing.T, cfg Config) {
+				want := defaults()
+				want.DB.DSN = "postgres://env@localhost/tryout"
+				if cfg != want {
+					t.Errorf("cfg = %+v, want defaults %+v", cfg, want)
+				}
+			},
+		},
+		{
+			name: "yaml over defaults",
+			yaml: true,
+			check: func(t *testing.T, cfg Config) {
+				if cfg.Addr != ":9000" || cfg.DB.MaxOpenConns != 50 || cfg.Shutdown.Timeout != 45*time.Second {
+					t.Errorf("yaml values not applied: %+v", cfg)
+				}
+				if cfg.RateLimit.Strict.Requests != 3 || cfg.RateLimit.Strict.Window != time.Minute {
+					t.Errorf("strict = %+v, want 3 requests with the default window", cfg.RateLimit.Strict)
+				}
+				if cfg.DB.MaxIdleConns != 30 || cfg.Tracing.Exporter != "none" {
+					t.Errorf("defaults not kept for keys missing from yaml: %+v", cfg)
+				}
+			},
+		},
+		{
+			name: "env over yaml",
+			yaml: true,
+			env: map[string]string{
+				"ADDR":                       ":7000",
+				"DB_DSN":                     "postgres://env@localhost/tryout",
+				"SHUTDOWN_TIMEOUT":           "10s",
+				"RATE_LIMIT_STRICT_REQUESTS": "7",
+				"RATE_LIMIT_ENABLED":         "false",
+			},
+			check: func(t *testing.T, cfg Config) {
+				if cfg.Addr != ":7000" || cfg.DB.DSN != "postgres://env@localhost/tryout" || cfg.Shutdown.Timeout != 10*time.Second {
+					t.Errorf("env values not applied: %+v", cfg)
+				}
+				if cfg.RateLimit.Strict.Requests != 7 || cfg.RateLimit.Enabled {
+					t.Errorf("rate limit = %+v, want 7 strict requests and disabled", cfg.RateLimit)
+				}
+				if cfg.DB.MaxOpenConns != 50 {
+					t.Errorf("max_open_conns = %d, want 50 from yaml", cfg.DB.MaxOpenConns)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setEnv(t, tt.env)
+			if tt.yaml {
+				t.Setenv("CONFIG_FILE", writeYAML(t, yaml))
+			}
+
+			cfg, err := Load()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			tt.check(t, cfg)
+		})
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		yaml string
+		env  map[string]string
+		// Semua potongan pesan ini harus muncul di error yang sama
+		want []string
+	}{
+		{
+			name: "parse errors are reported together",
+			env: map[string]string{
+				"DB_DSN":             "postgres://localhost/tryout",
+				"DB_MAX_OPEN_CONNS":  "banyak",
+				"SHUTDOWN_TIMEOUT":   "30",
+				"RATE_LIMIT_ENABLED": "mungkin",
+			},
+			want: []string{
+				`DB_MAX_OPEN_CONNS: harus berupa angka (integer) (nilai saat ini: "banyak")`,
+				`SHUTDOWN_TIMEOUT: harus berupa durasi`,
+				`RATE_LIMIT_ENABLED: harus berupa true atau false`,
+			},
+		},
+		{
+			name: "required and range rules",
+			env: map[string]string{
+				"DB_MAX_OPEN_CONNS":          "10",
+				"DB_MAX_IDLE_CONNS":          "20",
+				"RATE_LIMIT_GLOBAL_REQUESTS": "0",
+				"OTEL_TRACES_EXPORTER":       "jaeger",
+			},
+			want: []string{
+				"DB_DSN (db.dsn): tidak valid, aturan required",
+				"DB_MAX_IDLE_CONNS (db.max_idle_conns): tidak valid, aturan ltefield=MaxOpenConns (nilai saat ini: 20)",
+				"RATE_LIMIT_GLOBAL_REQUESTS (rate_limit.global.requests): tidak valid, aturan gte=1",
+				"OTEL_TRACES_EXPORTER (tracing.exporter): tidak valid, aturan oneof=none stdout otlp",
+			},
+		},
+		{
+			name: "unknown yaml key",
+			yaml: "db:\n  dns: postgres://localhost/tryout\n",
+			want: []string{"field dns not found"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setEnv(t, tt.env)
+			if tt.yaml != "" {
+				t.Setenv("CONFIG_FILE", writeYAML(t, tt.yaml))
+			}
+
+			_, err := Load()
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error does not contain %q:\n%v", want, err)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadMissingConfigFile(t *testing.T) {
+	setEnv(t, map[string]string{"CONFIG_FILE": filepath.Join(t.TempDir(), "missing.yaml")})
+
+	if _, err := Load(); err == nil || !strings.Contains(err.Error(), "CONFIG_FILE") {
+		t.Errorf("error = %v, want a CONFIG_FILE error", err)
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	tests := []struct {
+		dsn  string
+		want string
+	}{
+		{"", ""},
+		{"postgres://app:rahasia@db:5432/tryout?sslmode=disable", "postgres://app:xxxxx@db:5432/tryout?sslmode=disable"},
+		{"postgres://app@db/tryout?password=rahasia&sslmode=disable", "postgres://app@db/tryout?password=xxxxx&sslmode=disable"},
+		{"postgres:///tryout?host=/var/run/postgresql&password=rahasia", "postgres:///tryout?host=%2Fvar%2Frun%2Fpostgresql&password=xxxxx"},
+		{"host=db user=app password=rahasia dbname=tryout", "host=db user=app password=xxxxx dbname=tryout"},
+		{"host=db password = 'ra hasia\\'x' sslpassword=kunci", "host=db password = xxxxx sslpassword=xxxxx"},
+		{"rahasia", "********"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dsn, func(t *testing.T) {
+			cfg := defaults()
+			cfg.DB.DSN = tt.dsn
+
+			got := cfg.Redacted()["db.dsn"]
+			if got != tt.want {
+				t.Errorf("redacted dsn = %q, want %q", got, tt.want)
+			}
+			if tt.dsn != "" && strings.Contains(got.(string), "rahasia") {
+				t.Errorf("password leaked: %q", got)
+			}
+		})
+	}
+}
+
+func TestRedactedKeys(t *testing.T) {
+	got := defaults().Redacted()
+
+	want := map[string]any{
+		"addr":                       ":8080",
+		"db.max_idle_time":           "15m0s",
+		"rate_limit.global.requests": 120,
+		"rate_limit.enabled":         true,
+		"tracing.exporter":           "none",
+	}
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("Redacted()[%q] = %v, want %v", key, got[key], value)
+		}
+	}
+}
+
+// Error validasi untuk field rahasia tidak boleh menampilkan nilainya
+func TestValidationErrorHidesSecrets(t *testing.T) {
+	cfg := defaults()
+	cfg.DB.DSN = ""
+
+	for _, err := range validate(cfg) {
+		if strings.Contains(err.Error(), "nilai saat ini") && strings.Contains(err.Error(), "DB_DSN") {
+			t.Errorf("secret field error shows its value: %v", err)
+		}
+	}
+}
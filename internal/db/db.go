@@ -30,3 +30,15 @@ func New(addr string, maxOpenConns int, maxIdleConns int, maxIdleTime string) (*
 
 	return db, nil
 }
+
+// SchemaVersion adalah nomor migrasi terakhir di cmd/migrate/migrations.
+// Naikkan setiap kali menambah file migrasi baru.
+const SchemaVersion = 6
+
+// MigrationVersion membaca versi migrasi yang tercatat oleh golang-migrate
+func MigrationVersion(ctx context.Context, db *sql.DB) (version int, dirty bool, err error) {
+	query := `SELECT version, dirty FROM schema_migrations LIMIT 1`
+
+	err = db.QueryRowContext(ctx, query).Scan(&version, &dirty)
+	return version, dirty, err
+}
@@ -0,0 +1,34 @@
+package db
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// TestSchemaVersionMatchesMigrations gagal jika file migrasi baru ditambahkan
+// tanpa menaikkan SchemaVersion, yang membuat /readyz gagal terus di production
+func TestSchemaVersionMatchesMigrations(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join("..", "..", "cmd", "migrate", "migrations", "*.up.sql"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("no migrations found in cmd/migrate/migrations")
+	}
+
+	latest := 0
+	for _, f := range files {
+		prefix, _, _ := strings.Cut(filepath.Base(f), "_")
+		version, err := strconv.Atoi(prefix)
+		if err != nil {
+			t.Fatalf("migration %s does not start with a version number", filepath.Base(f))
+		}
+		latest = max(latest, version)
+	}
+
+	if latest != SchemaVersion {
+		t.Errorf("latest migration is %06d but SchemaVersion is %d", latest, SchemaVersion)
+	}
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+type Comment struct {
+	ID         int64     `json:"id"`
+	QuestionID int64     `json:"question_id"`
+	ParentID   *int64    `json:"parent_id"`
+	Body       string    `json:"body"`
+	BodyHTML   string    `json:"body_html"`
+	IsOfficial bool      `json:"is_official"`
+	IsHidden   bool      `json:"is_hidden"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	Replies    []Comment `json:"replies,omitempty"`
+}
@@ -1,7 +1,8 @@
 package models
 
 type Option struct {
-	Code  string `json:"code"`
-	Text  string `json:"text"`
-	Score int    `json:"score"`
+	Code     string `json:"code"`
+	Text     string `json:"text"`
+	TextHTML string `json:"text_html"`
+	Score    int    `json:"score"`
 }
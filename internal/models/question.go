@@ -11,14 +11,51 @@ type Question struct {
 	ID                  int64           `json:"id"`
 	Category            string          `json:"category"`
 	QuestionText        string          `json:"question_text"`
+	QuestionTextHTML    string          `json:"question_text_html"`
 	QuestionImageURL    *string         `json:"question_image_url"`
 	Options             QuestionOptions `json:"options"`
 	Explanation         string          `json:"explanation"`
+	ExplanationHTML     string          `json:"explanation_html"`
 	ExplanationImageURL *string         `json:"explanation_image_url"`
+	Status              string          `json:"status"`
 	CreatedAt           time.Time       `json:"created_at"`
 	UpdatedAt           time.Time       `json:"updated_at"`
 }
 
+const (
+	QuestionStatusDraft     = "draft"
+	QuestionStatusInReview  = "in_review"
+	QuestionStatusApproved  = "approved"
+	QuestionStatusPublished = "published"
+	QuestionStatusRetired   = "retired"
+)
+
+// Daftar perpindahan status yang diperbolehkan:
+// draft -> in_review -> approved -> published -> retired
+var questionStatusTransitions = map[string][]string{
+	QuestionStatusDraft:     {QuestionStatusInReview},
+	QuestionStatusInReview:  {QuestionStatusDraft, QuestionStatusApproved},
+	QuestionStatusApproved:  {QuestionStatusDraft, QuestionStatusPublished},
+	QuestionStatusPublished: {QuestionStatusRetired},
+	QuestionStatusRetired:   {QuestionStatusDraft},
+}
+
+func CanTransitionQuestionStatus(from, to string) bool {
+	for _, s := range questionStatusTransitions[from] {
+		if s == to {
+			return true
+		}
+	}
+	return false
+}
+
+// QuestionEditRequiresReview menandai status yang isinya sudah disetujui.
+// Mengubah isi soal dengan status ini harus mengembalikannya ke draft
+// supaya direview ulang sebelum tayang.
+func QuestionEditRequiresReview(status string) bool {
+	return status == QuestionStatusApproved || status == QuestionStatusPublished
+}
+
 type QuestionOptions []Option
 
 func (qo QuestionOptions) Value() (driver.Value, error) {
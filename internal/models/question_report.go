@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+const (
+	ReportReasonWrongKey           = "wrong_key"
+	ReportReasonTypo               = "typo"
+	ReportReasonUnclearImage       = "unclear_image"
+	ReportReasonOutdatedRegulation = "outdated_regulation"
+)
+
+const (
+	ReportStatusOpen       = "open"
+	ReportStatusInProgress = "in_progress"
+	ReportStatusResolved   = "resolved"
+	ReportStatusRejected   = "rejected"
+)
+
+type QuestionReport struct {
+	ID             int64      `json:"id"`
+	QuestionID     int64      `json:"question_id"`
+	Source         string     `json:"source"`
+	Reason         string     `json:"reason"`
+	Description    string     `json:"description"`
+	Status         string     `json:"status"`
+	ResolutionNote string     `json:"resolution_note"`
+	ResolvedAt     *time.Time `json:"resolved_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
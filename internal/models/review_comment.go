@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+type ReviewComment struct {
+	ID         int64     `json:"id"`
+	QuestionID int64     `json:"question_id"`
+	Field      string    `json:"field"`
+	Body       string    `json:"body"`
+	CreatedAt  time.Time `json:"created_at"`
+}
@@ -0,0 +1,110 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+	window time.Duration
+}
+
+// MemoryLimiter adalah token bucket yang disimpan di memori proses.
+// Cocok untuk satu instance; bucket yang tidak aktif dibersihkan berkala.
+type MemoryLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	now     func() time.Time
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+func NewMemoryLimiter(cleanupInterval time.Duration) *MemoryLimiter {
+	l := &MemoryLimiter{
+		buckets: make(map[string]*bucket),
+		now:     time.Now,
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+
+	go l.cleanup(cleanupInterval)
+
+	return l
+}
+
+func (l *MemoryLimiter) Allow(ctx context.Context, key string, limit Limit) (Result, error) {
+	capacity := float64(limit.Requests)
+	rate := capacity / limit.Window.Seconds()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: capacity, last: now}
+		l.buckets[key] = b
+	}
+
+	// Isi ulang token sesuai waktu yang sudah lewat sejak permintaan terakhir
+	b.tokens = math.Min(capacity, b.tokens+now.Sub(b.last).Seconds()*rate)
+	b.last = now
+	b.window = limit.Window
+
+	res := Result{Limit: limit.Requests}
+	if b.tokens >= 1 {
+		b.tokens--
+		res.Allowed = true
+	} else {
+		res.RetryAfter = secondsToDuration((1 - b.tokens) / rate)
+	}
+
+	res.Remaining = int(b.tokens)
+	res.ResetAfter = secondsToDuration((capacity - b.tokens) / rate)
+
+	return res, nil
+}
+
+// Stop menghentikan goroutine pembersih dan menunggu sampai benar-benar berhenti
+func (l *MemoryLimiter) Stop() {
+	close(l.stop)
+	<-l.done
+}
+
+func (l *MemoryLimiter) cleanup(interval time.Duration) {
+	defer close(l.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-l.stop:
+			return
+		case <-ticker.C:
+			l.removeIdle()
+		}
+	}
+}
+
+// removeIdle menghapus bucket yang tidak dipakai selama lebih dari satu window
+func (l *MemoryLimiter) removeIdle() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	for key, b := range l.buckets {
+		// Setelah satu window tanpa permintaan bucket pasti sudah penuh lagi
+		if now.Sub(b.last) > b.window {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+func secondsToDuration(s float64) time.Duration {
+	return time.Duration(math.Ceil(s * float64(time.Second)))
+}
@@ -0,0 +1,100 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+// fakeClock menggantikan time.Now supaya pengisian token bisa diuji tanpa sleep
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newTestLimiter(t *testing.T) (*MemoryLimiter, *fakeClock) {
+	t.Helper()
+
+	clock := &fakeClock{now: time.Date(2026, 1, 1, 8, 0, 0, 0, time.UTC)}
+	l := NewMemoryLimiter(time.Hour)
+	l.now = clock.Now
+	t.Cleanup(l.Stop)
+
+	return l, clock
+}
+
+func TestMemoryLimiterAllow(t *testing.T) {
+	l, clock := newTestLimiter(t)
+
+	// 2 permintaan per 2 detik: satu token terisi setiap detik
+	limit := Limit{Requests: 2, Window: 2 * time.Second}
+
+	steps := []struct {
+		name    string
+		advance time.Duration
+		want    Result
+	}{
+		{"first request", 0, Result{Allowed: true, Limit: 2, Remaining: 1, ResetAfter: time.Second}},
+		{"burst uses the last token", 0, Result{Allowed: true, Limit: 2, Remaining: 0, ResetAfter: 2 * time.Second}},
+		{"empty bucket", 0, Result{Allowed: false, Limit: 2, Remaining: 0, ResetAfter: 2 * time.Second, RetryAfter: time.Second}},
+		{"half a token refilled", 500 * time.Millisecond, Result{Allowed: false, Limit: 2, Remaining: 0, ResetAfter: 1500 * time.Millisecond, RetryAfter: 500 * time.Millisecond}},
+		{"one token refilled", 500 * time.Millisecond, Result{Allowed: true, Limit: 2, Remaining: 0, ResetAfter: 2 * time.Second}},
+		{"refill is capped at the limit", time.Hour, Result{Allowed: true, Limit: 2, Remaining: 1, ResetAfter: time.Second}},
+	}
+
+	for _, step := range steps {
+		clock.Advance(step.advance)
+
+		got, err := l.Allow(t.Context(), "ip:1", limit)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", step.name, err)
+		}
+		if got != step.want {
+			t.Errorf("%s: got %+v, want %+v", step.name, got, step.want)
+		}
+	}
+}
+
+func TestMemoryLimiterKeysAreIndependent(t *testing.T) {
+	l, _ := newTestLimiter(t)
+	limit := Limit{Requests: 1, Window: time.Minute}
+
+	if res, _ := l.Allow(t.Context(), "ip:1", limit); !res.Allowed {
+		t.Fatal("first request for ip:1 was denied")
+	}
+	if res, _ := l.Allow(t.Context(), "ip:1", limit); res.Allowed {
+		t.Error("second request for ip:1 was allowed")
+	}
+	if res, _ := l.Allow(t.Context(), "ip:2", limit); !res.Allowed {
+		t.Error("ip:2 shares a bucket with ip:1")
+	}
+}
+
+func TestMemoryLimiterRemoveIdle(t *testing.T) {
+	l, clock := newTestLimiter(t)
+
+	l.Allow(t.Context(), "short", Limit{Requests: 1, Window: time.Second})
+	l.Allow(t.Context(), "long", Limit{Requests: 1, Window: time.Minute})
+
+	clock.Advance(time.Second)
+	l.removeIdle()
+	if len(l.buckets) != 2 {
+		t.Fatalf("after exactly one window: %d buckets, want 2", len(l.buckets))
+	}
+
+	clock.Advance(time.Millisecond)
+	l.removeIdle()
+	if _, ok := l.buckets["short"]; ok {
+		t.Error("idle bucket was not removed")
+	}
+	if _, ok := l.buckets["long"]; !ok {
+		t.Error("bucket still inside its window was removed")
+	}
+
+	// Bucket yang dihapus dibuat ulang dalam keadaan penuh
+	if res, _ := l.Allow(t.Context(), "short", Limit{Requests: 1, Window: time.Second}); !res.Allowed {
+		t.Error("request after cleanup was denied")
+	}
+}
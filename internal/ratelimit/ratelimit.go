@@ -0,0 +1,29 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Limit mengizinkan Requests permintaan per Window. Token diisi ulang
+// secara merata sepanjang Window, jadi burst maksimal sama dengan Requests.
+type Limit struct {
+	Requests int
+	Window   time.Duration
+}
+
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// ResetAfter adalah waktu sampai bucket penuh kembali
+	ResetAfter time.Duration
+	// RetryAfter hanya diisi jika Allowed bernilai false
+	RetryAfter time.Duration
+}
+
+// Limiter bisa diganti dengan backend bersama (misal Redis) saat API
+// dijalankan lebih dari satu instance. Key sudah termasuk nama route.
+type Limiter interface {
+	Allow(ctx context.Context, key string, limit Limit) (Result, error)
+}
@@ -0,0 +1,149 @@
+package richtext
+
+import (
+	"bytes"
+	"strconv"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// Perintah LaTeX yang boleh dipakai di soal. Rendering rumus dilakukan di
+// frontend (KaTeX), jadi daftar ini sengaja dibatasi pada perintah yang
+// aman dan umum dipakai untuk soal numerik TIU.
+var allowedTeXCommands = map[string]bool{
+	"frac": true, "dfrac": true, "tfrac": true, "sqrt": true, "binom": true,
+	"times": true, "div": true, "cdot": true, "pm": true, "mp": true,
+	"le": true, "leq": true, "ge": true, "geq": true, "lt": true, "gt": true,
+	"ne": true, "neq": true, "approx": true, "equiv": true,
+	"left": true, "right": true, "text": true, "mathrm": true,
+	"overline": true, "bar": true, "hat": true, "vec": true,
+	"sum": true, "prod": true, "infty": true, "ldots": true, "cdots": true,
+	"log": true, "ln": true, "sin": true, "cos": true, "tan": true,
+	"circ": true, "angle": true, "triangle": true, "perp": true, "parallel": true,
+	"in": true, "notin": true, "subset": true, "cup": true, "cap": true, "emptyset": true,
+	"rightarrow": true, "Rightarrow": true, "leftrightarrow": true, "quad": true, "qquad": true,
+	"alpha": true, "beta": true, "gamma": true, "delta": true, "Delta": true, "theta": true,
+	"lambda": true, "mu": true, "pi": true, "sigma": true, "omega": true,
+}
+
+func validateTeX(src []byte) error {
+	depth := 0
+	for i := 0; i < len(src); i++ {
+		switch c := src[i]; c {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth < 0 {
+				return &Error{Code: CodeTeXExtraBrace}
+			}
+		case '\\':
+			j := i + 1
+			for j < len(src) && isASCIILetter(src[j]) {
+				j++
+			}
+			if j == i+1 {
+				// Perintah satu simbol seperti \, \% \{ \} dan \\ (ganti baris)
+				if j < len(src) && bytes.IndexByte([]byte(`,;!%{} \`), src[j]) >= 0 {
+					i = j
+					continue
+				}
+				return &Error{Code: CodeTeXInvalid, Arg: strconv.Itoa(i + 1)}
+			}
+			name := string(src[i+1 : j])
+			if !allowedTeXCommands[name] {
+				return &Error{Code: CodeTeXUnsupported, Arg: name}
+			}
+			i = j - 1
+		}
+	}
+
+	if depth != 0 {
+		return &Error{Code: CodeTeXUnbalanced}
+	}
+
+	return nil
+}
+
+func isASCIILetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+var kindMath = ast.NewNodeKind("Math")
+
+// mathNode menyimpan rumus $...$ (inline) atau $$...$$ (display) apa adanya
+type mathNode struct {
+	ast.BaseInline
+	display bool
+	value   []byte
+}
+
+func (n *mathNode) Kind() ast.NodeKind { return kindMath }
+
+func (n *mathNode) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"Value": string(n.value)}, nil)
+}
+
+type mathParser struct{}
+
+func (mathParser) Trigger() []byte { return []byte{'$'} }
+
+func (mathParser) Parse(parent ast.Node, block text.Reader, pc parser.Context) ast.Node {
+	line, _ := block.PeekLine()
+
+	delim := 1
+	if len(line) > 1 && line[1] == '$' {
+		delim = 2
+	}
+
+	// Sama seperti pandoc: $ pembuka tidak boleh diikuti spasi dan $ penutup
+	// tidak boleh didahului spasi, supaya "Rp 5$ dan 6$" tidak dianggap rumus.
+	rest := line[delim:]
+	if len(rest) == 0 || rest[0] == ' ' {
+		return nil
+	}
+
+	end := bytes.Index(rest, line[:delim])
+	if end <= 0 || rest[end-1] == ' ' || rest[end-1] == '\\' {
+		return nil
+	}
+
+	node := &mathNode{display: delim == 2, value: append([]byte(nil), rest[:end]...)}
+	block.Advance(delim*2 + end)
+
+	return node
+}
+
+type mathRenderer struct{}
+
+func (mathRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(kindMath, func(w util.BufWriter, source []byte, n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		m := n.(*mathNode)
+		class := "math math-inline"
+		if m.display {
+			class = "math math-display"
+		}
+
+		w.WriteString(`<span class="` + class + `">`)
+		w.Write(util.EscapeHTML(m.value))
+		w.WriteString("</span>")
+
+		return ast.WalkSkipChildren, nil
+	})
+}
+
+type mathExtension struct{}
+
+func (mathExtension) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(parser.WithInlineParsers(util.Prioritized(mathParser{}, 500)))
+	m.Renderer().AddOptions(renderer.WithNodeRenderers(util.Prioritized(mathRenderer{}, 500)))
+}
@@ -0,0 +1,54 @@
+package richtext
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateTeX(t *testing.T) {
+	tests := []struct {
+		tex  string
+		code string
+		arg  string
+	}{
+		{`\frac{1}{2}`, "", ""},
+		{`a \\ b`, "", ""},
+		{`x = 1 \\ y = 2 \\`, "", ""},
+		{`50\% \{a\} \, b`, "", ""},
+		{`\foo`, CodeTeXUnsupported, "foo"},
+		{`a \@ b`, CodeTeXInvalid, "3"},
+		{`a \`, CodeTeXInvalid, "3"},
+		{`{a`, CodeTeXUnbalanced, ""},
+		{`a}`, CodeTeXExtraBrace, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tex, func(t *testing.T) {
+			err := validateTeX([]byte(tt.tex))
+			if tt.code == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var re *Error
+			if !errors.As(err, &re) {
+				t.Fatalf("error = %v, want *Error", err)
+			}
+			if re.Code != tt.code || re.Arg != tt.arg {
+				t.Errorf("error = %+v, want code %q arg %q", re, tt.code, tt.arg)
+			}
+		})
+	}
+}
+
+// Pesan untuk user ada di katalog i18n, Error() hanya membawa kodenya
+func TestErrorReturnsCode(t *testing.T) {
+	if got := (&Error{Code: CodeHTML}).Error(); got != CodeHTML {
+		t.Errorf("Error() = %q, want %q", got, CodeHTML)
+	}
+	if got := (&Error{Code: CodeTeXUnsupported, Arg: "foo"}).Error(); got != "tex_unsupported_command: foo" {
+		t.Errorf("Error() = %q, want the code and its argument", got)
+	}
+}
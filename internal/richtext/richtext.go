@@ -0,0 +1,108 @@
+package richtext
+
+import (
+	"bytes"
+	"regexp"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	extast "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+var md = goldmark.New(
+	goldmark.WithExtensions(extension.Table, mathExtension{}),
+)
+
+// Sanitizer tetap dipasang walaupun markup sudah divalidasi,
+// sebagai lapisan pengaman terakhir sebelum HTML disimpan.
+var policy = func() *bluemonday.Policy {
+	p := bluemonday.NewPolicy()
+	p.AllowElements("p", "br", "em", "strong", "code", "ul", "ol", "li", "blockquote",
+		"table", "thead", "tbody", "tr", "th", "td")
+	p.AllowAttrs("start").Matching(bluemonday.Integer).OnElements("ol")
+	p.AllowStyles("text-align").MatchingEnum("left", "center", "right").OnElements("th", "td")
+	p.AllowAttrs("class").Matching(regexp.MustCompile(`^math math-(inline|display)$`)).OnElements("span")
+	return p
+}()
+
+const (
+	CodeHeading        = "heading"
+	CodeImage          = "image"
+	CodeLink           = "link"
+	CodeHTML           = "html"
+	CodeCodeBlock      = "code_block"
+	CodeUnsupported    = "unsupported"
+	CodeTeXExtraBrace  = "tex_extra_brace"
+	CodeTeXUnbalanced  = "tex_unbalanced"
+	CodeTeXInvalid     = "tex_invalid_command"
+	CodeTeXUnsupported = "tex_unsupported_command"
+)
+
+// Error menjelaskan markup yang ditolak. Code stabil sehingga pemanggil bisa
+// menerjemahkan pesannya, Arg berisi detail seperti nama perintah LaTeX.
+type Error struct {
+	Code string
+	Arg  string
+}
+
+// Error hanya mengembalikan kode (dan argumennya) untuk log. Pesan untuk user
+// diterjemahkan oleh pemanggil dari katalog i18n berdasarkan Code dan Arg.
+func (e *Error) Error() string {
+	if e.Arg == "" {
+		return e.Code
+	}
+	return e.Code + ": " + e.Arg
+}
+
+// Render memvalidasi source (subset markdown + LaTeX) lalu mengembalikan HTML yang aman.
+// Markup yang ditolak dikembalikan sebagai *Error.
+func Render(source string) (string, error) {
+	src := []byte(source)
+	doc := md.Parser().Parse(text.NewReader(src))
+
+	if err := validate(doc, src); err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := md.Renderer().Render(&buf, src, doc); err != nil {
+		return "", err
+	}
+
+	return string(bytes.TrimSpace(policy.SanitizeBytes(buf.Bytes()))), nil
+}
+
+func validate(doc ast.Node, src []byte) error {
+	return ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		switch n := n.(type) {
+		case *ast.Document, *ast.Paragraph, *ast.TextBlock, *ast.Text, *ast.String,
+			*ast.Emphasis, *ast.CodeSpan, *ast.List, *ast.ListItem, *ast.Blockquote,
+			*extast.Table, *extast.TableHeader, *extast.TableRow, *extast.TableCell:
+			return ast.WalkContinue, nil
+		case *mathNode:
+			if err := validateTeX(n.value); err != nil {
+				return ast.WalkStop, err
+			}
+			return ast.WalkSkipChildren, nil
+		case *ast.Heading:
+			return ast.WalkStop, &Error{Code: CodeHeading}
+		case *ast.Image:
+			return ast.WalkStop, &Error{Code: CodeImage}
+		case *ast.Link, *ast.AutoLink:
+			return ast.WalkStop, &Error{Code: CodeLink}
+		case *ast.RawHTML, *ast.HTMLBlock:
+			return ast.WalkStop, &Error{Code: CodeHTML}
+		case *ast.CodeBlock, *ast.FencedCodeBlock:
+			return ast.WalkStop, &Error{Code: CodeCodeBlock}
+		default:
+			return ast.WalkStop, &Error{Code: CodeUnsupported, Arg: n.Kind().String()}
+		}
+	})
+}
@@ -0,0 +1,231 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/ReyviRahman/to-backend/internal/models"
+	"github.com/lib/pq"
+)
+
+type CommentStore struct {
+	db *sql.DB
+}
+
+func (s *CommentStore) Create(ctx context.Context, comment *models.Comment) (err error) {
+	ctx, op := startQuery(ctx, "comments", "Create")
+	defer func() { op.end(err) }()
+
+	query := `
+		INSERT INTO question_comments (question_id, parent_id, body, body_html)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, is_official, is_hidden, created_at, updated_at
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	return s.db.QueryRowContext(ctx, query,
+		comment.QuestionID,
+		comment.ParentID,
+		comment.Body,
+		comment.BodyHTML,
+	).Scan(&comment.ID, &comment.IsOfficial, &comment.IsHidden, &comment.CreatedAt, &comment.UpdatedAt)
+}
+
+func (s *CommentStore) GetByID(ctx context.Context, id int64) (_ *models.Comment, err error) {
+	ctx, op := startQuery(ctx, "comments", "GetByID")
+	defer func() { op.end(err) }()
+
+	query := `
+		SELECT id, question_id, parent_id, body, body_html, is_official, is_hidden, created_at, updated_at
+		FROM question_comments
+		WHERE id = $1
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	var c models.Comment
+	err = s.db.QueryRowContext(ctx, query, id).Scan(
+		&c.ID,
+		&c.QuestionID,
+		&c.ParentID,
+		&c.Body,
+		&c.BodyHTML,
+		&c.IsOfficial,
+		&c.IsHidden,
+		&c.CreatedAt,
+		&c.UpdatedAt,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &c, nil
+}
+
+// GetByQuestionID mengambil satu halaman komentar utama beserta balasannya.
+// Komentar yang disembunyikan moderator tidak ikut ditampilkan.
+func (s *CommentStore) GetByQuestionID(ctx context.Context, questionID int64, cq PaginatedCommentQuery) (_ []models.Comment, _ MetaData, err error) {
+	ctx, op := startQuery(ctx, "comments", "GetByQuestionID")
+	defer func() { op.end(err) }()
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	var totalItems int
+	countQuery := `
+		SELECT COUNT(id)
+		FROM question_comments
+		WHERE question_id = $1 AND parent_id IS NULL AND NOT is_hidden
+	`
+
+	err = s.db.QueryRowContext(ctx, countQuery, questionID).Scan(&totalItems)
+	if err != nil {
+		return nil, MetaData{}, err
+	}
+
+	query := `
+		SELECT id, question_id, parent_id, body, body_html, is_official, is_hidden, created_at, updated_at
+		FROM question_comments
+		WHERE question_id = $1 AND parent_id IS NULL AND NOT is_hidden
+		ORDER BY created_at ASC
+		LIMIT $2 OFFSET $3
+	`
+
+	threads, err := s.queryComments(ctx, query, questionID, cq.Limit, cq.Offset)
+	if err != nil {
+		return nil, MetaData{}, err
+	}
+	rowCount := len(threads)
+
+	if len(threads) > 0 {
+		parentIDs := make([]int64, len(threads))
+		for i, t := range threads {
+			parentIDs[i] = t.ID
+		}
+
+		// Jawaban resmi dari editor selalu tampil paling atas di setiap thread
+		repliesQuery := `
+			SELECT id, question_id, parent_id, body, body_html, is_official, is_hidden, created_at, updated_at
+			FROM question_comments
+			WHERE parent_id = ANY($1) AND NOT is_hidden
+			ORDER BY is_official DESC, created_at ASC
+		`
+
+		replies, err := s.queryComments(ctx, repliesQuery, pq.Array(parentIDs))
+		if err != nil {
+			return nil, MetaData{}, err
+		}
+		rowCount += len(replies)
+
+		index := make(map[int64]int, len(threads))
+		for i, t := range threads {
+			index[t.ID] = i
+		}
+		for _, reply := range replies {
+			i := index[*reply.ParentID]
+			threads[i].Replies = append(threads[i].Replies, reply)
+		}
+	}
+
+	op.setRows(rowCount)
+
+	return threads, newMetaData(totalItems, cq.Limit, cq.Offset), nil
+}
+
+func (s *CommentStore) queryComments(ctx context.Context, query string, args ...any) ([]models.Comment, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	comments := []models.Comment{}
+	for rows.Next() {
+		var c models.Comment
+		err := rows.Scan(
+			&c.ID,
+			&c.QuestionID,
+			&c.ParentID,
+			&c.Body,
+			&c.BodyHTML,
+			&c.IsOfficial,
+			&c.IsHidden,
+			&c.CreatedAt,
+			&c.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		comments = append(comments, c)
+	}
+
+	return comments, rows.Err()
+}
+
+func (s *CommentStore) Update(ctx context.Context, comment *models.Comment) (err error) {
+	ctx, op := startQuery(ctx, "comments", "Update")
+	defer func() { op.end(err) }()
+
+	query := `
+		UPDATE question_comments
+		SET is_official = $1, is_hidden = $2, updated_at = NOW()
+		WHERE id = $3
+		RETURNING updated_at
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	err = s.db.QueryRowContext(ctx, query,
+		comment.IsOfficial,
+		comment.IsHidden,
+		comment.ID,
+	).Scan(&comment.UpdatedAt)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrNotFound
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *CommentStore) Delete(ctx context.Context, id int64) (err error) {
+	ctx, op := startQuery(ctx, "comments", "Delete")
+	defer func() { op.end(err) }()
+
+	query := `DELETE FROM question_comments WHERE id = $1`
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	res, err := s.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	op.setRows(int(rows))
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
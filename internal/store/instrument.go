@@ -0,0 +1,64 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var queryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "tryout",
+	Subsystem: "store",
+	Name:      "query_duration_seconds",
+	Help:      "Durasi query database per method store.",
+	Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+}, []string{"store", "method"})
+
+var tracer = otel.Tracer("github.com/ReyviRahman/to-backend/internal/store")
+
+// queryOp mencatat satu pemanggilan method store sebagai span dan metrik durasi
+type queryOp struct {
+	span   trace.Span
+	start  time.Time
+	store  string
+	method string
+}
+
+// startQuery dipakai di awal setiap method store, dengan return value bernama err:
+//
+//	ctx, op := startQuery(ctx, "questions", "Create")
+//	defer func() { op.end(err) }()
+func startQuery(ctx context.Context, store, method string) (context.Context, *queryOp) {
+	ctx, span := tracer.Start(ctx, store+"."+method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("db.system.name", "postgresql"),
+			attribute.String("db.statement.name", store+"."+method),
+		),
+	)
+
+	return ctx, &queryOp{span: span, start: time.Now(), store: store, method: method}
+}
+
+func (op *queryOp) setRows(n int) {
+	op.span.SetAttributes(attribute.Int("db.response.returned_rows", n))
+}
+
+func (op *queryOp) end(err error) {
+	queryDuration.WithLabelValues(op.store, op.method).Observe(time.Since(op.start).Seconds())
+
+	// Data tidak ditemukan adalah hasil normal, bukan kegagalan query
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		op.span.RecordError(err)
+		op.span.SetStatus(codes.Error, err.Error())
+	}
+
+	op.span.End()
+}
@@ -0,0 +1,424 @@
+package store
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ReyviRahman/to-backend/internal/models"
+)
+
+// NewMemoryStorage membuat Storage yang menyimpan data di memori, dengan
+// perilaku yang sama seperti store Postgres (urutan, filter, paginasi, error
+// sentinel dan ON DELETE CASCADE). Dipakai untuk test tanpa database.
+func NewMemoryStorage() Storage {
+	m := &memoryDB{
+		questions:      make(map[int64]models.Question),
+		reviewComments: make(map[int64]models.ReviewComment),
+		reports:        make(map[int64]models.QuestionReport),
+		comments:       make(map[int64]models.Comment),
+		lastID:         make(map[string]int64),
+	}
+
+	return Storage{
+		Questions:      &MemoryQuestionStore{m},
+		ReviewComments: &MemoryReviewCommentStore{m},
+		Reports:        &MemoryQuestionReportStore{m},
+		Comments:       &MemoryCommentStore{m},
+	}
+}
+
+// memoryDB dipakai bersama oleh semua store memori supaya hapus soal
+// ikut menghapus data turunannya, sama seperti foreign key di database
+type memoryDB struct {
+	mu             sync.Mutex
+	questions      map[int64]models.Question
+	reviewComments map[int64]models.ReviewComment
+	reports        map[int64]models.QuestionReport
+	comments       map[int64]models.Comment
+	lastID         map[string]int64
+}
+
+// nextID meniru BIGSERIAL: ID per tabel dimulai dari 1 dan tidak pernah dipakai ulang
+func (m *memoryDB) nextID(table string) int64 {
+	m.lastID[table]++
+	return m.lastID[table]
+}
+
+// paginate memotong hasil sesuai LIMIT/OFFSET di SQL
+func paginate[T any](items []T, limit, offset int) []T {
+	if offset >= len(items) {
+		return nil
+	}
+	end := len(items)
+	if limit >= 0 && offset+limit < end {
+		end = offset + limit
+	}
+	return items[offset:end]
+}
+
+// byCreatedAt mengurutkan berdasarkan created_at, dengan ID sebagai penentu
+// jika waktunya sama (insert berurutan bisa mendapat timestamp yang sama)
+func byCreatedAt(aTime time.Time, aID int64, bTime time.Time, bID int64) bool {
+	if !aTime.Equal(bTime) {
+		return aTime.Before(bTime)
+	}
+	return aID < bID
+}
+
+func copyQuestion(q models.Question) *models.Question {
+	q.Options = append(models.QuestionOptions(nil), q.Options...)
+	return &q
+}
+
+type MemoryQuestionStore struct {
+	m *memoryDB
+}
+
+func (s *MemoryQuestionStore) Create(ctx context.Context, question *models.Question) error {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	now := time.Now()
+	question.ID = s.m.nextID("questions")
+	question.Status = models.QuestionStatusDraft
+	question.CreatedAt = now
+	question.UpdatedAt = now
+
+	s.m.questions[question.ID] = *copyQuestion(*question)
+
+	return nil
+}
+
+func (s *MemoryQuestionStore) GetByID(ctx context.Context, id int64) (*models.Question, error) {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	q, ok := s.m.questions[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return copyQuestion(q), nil
+}
+
+func (s *MemoryQuestionStore) GetQuestions(ctx context.Context, qq PaginatedQuestionQuery) ([]models.Question, MetaData, error) {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	search := strings.ToLower(qq.Search)
+
+	var matched []models.Question
+	for _, q := range s.m.questions {
+		if search != "" && !strings.Contains(strings.ToLower(q.QuestionText), search) {
+			continue
+		}
+		if qq.Status != "" && q.Status != qq.Status {
+			continue
+		}
+		matched = append(matched, *copyQuestion(q))
+	}
+
+	// Terbaru lebih dulu, sama seperti ORDER BY created_at DESC
+	sort.Slice(matched, func(i, j int) bool {
+		return byCreatedAt(matched[j].CreatedAt, matched[j].ID, matched[i].CreatedAt, matched[i].ID)
+	})
+
+	return paginate(matched, qq.Limit, qq.Offset), newMetaData(len(matched), qq.Limit, qq.Offset), nil
+}
+
+func (s *MemoryQuestionStore) Update(ctx context.Context, question *models.Question, status string) error {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	stored, ok := s.m.questions[question.ID]
+	if !ok || stored.Status != question.Status {
+		return ErrConflict
+	}
+
+	stored.Category = question.Category
+	stored.QuestionText = question.QuestionText
+	stored.QuestionTextHTML = question.QuestionTextHTML
+	stored.Options = append(models.QuestionOptions(nil), question.Options...)
+	stored.Explanation = question.Explanation
+	stored.ExplanationHTML = question.ExplanationHTML
+	stored.Status = status
+	stored.UpdatedAt = time.Now()
+	s.m.questions[question.ID] = stored
+
+	question.Status = status
+	question.UpdatedAt = stored.UpdatedAt
+
+	return nil
+}
+
+func (s *MemoryQuestionStore) UpdateStatus(ctx context.Context, question *models.Question, status string) error {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	stored, ok := s.m.questions[question.ID]
+	if !ok || stored.Status != question.Status {
+		return ErrConflict
+	}
+
+	stored.Status = status
+	stored.UpdatedAt = time.Now()
+	s.m.questions[question.ID] = stored
+
+	question.Status = status
+	question.UpdatedAt = stored.UpdatedAt
+
+	return nil
+}
+
+func (s *MemoryQuestionStore) Delete(ctx context.Context, id int64) error {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	if _, ok := s.m.questions[id]; !ok {
+		return ErrNotFound
+	}
+	delete(s.m.questions, id)
+
+	for cid, c := range s.m.reviewComments {
+		if c.QuestionID == id {
+			delete(s.m.reviewComments, cid)
+		}
+	}
+	for rid, rp := range s.m.reports {
+		if rp.QuestionID == id {
+			delete(s.m.reports, rid)
+		}
+	}
+	for cid, c := range s.m.comments {
+		if c.QuestionID == id {
+			delete(s.m.comments, cid)
+		}
+	}
+
+	return nil
+}
+
+type MemoryReviewCommentStore struct {
+	m *memoryDB
+}
+
+func (s *MemoryReviewCommentStore) Create(ctx context.Context, comment *models.ReviewComment) error {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	comment.ID = s.m.nextID("question_review_comments")
+	comment.CreatedAt = time.Now()
+	s.m.reviewComments[comment.ID] = *comment
+
+	return nil
+}
+
+func (s *MemoryReviewCommentStore) GetByQuestionID(ctx context.Context, questionID int64) ([]models.ReviewComment, error) {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	comments := []models.ReviewComment{}
+	for _, c := range s.m.reviewComments {
+		if c.QuestionID == questionID {
+			comments = append(comments, c)
+		}
+	}
+
+	sort.Slice(comments, func(i, j int) bool {
+		return byCreatedAt(comments[i].CreatedAt, comments[i].ID, comments[j].CreatedAt, comments[j].ID)
+	})
+
+	return comments, nil
+}
+
+type MemoryQuestionReportStore struct {
+	m *memoryDB
+}
+
+func (s *MemoryQuestionReportStore) Create(ctx context.Context, report *models.QuestionReport) error {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	now := time.Now()
+	report.ID = s.m.nextID("question_reports")
+	report.Status = models.ReportStatusOpen
+	report.CreatedAt = now
+	report.UpdatedAt = now
+	s.m.reports[report.ID] = *report
+
+	return nil
+}
+
+func (s *MemoryQuestionReportStore) GetByID(ctx context.Context, id int64) (*models.QuestionReport, error) {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	rp, ok := s.m.reports[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return &rp, nil
+}
+
+func (s *MemoryQuestionReportStore) GetReports(ctx context.Context, rq PaginatedReportQuery) ([]models.QuestionReport, MetaData, error) {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	var matched []models.QuestionReport
+	for _, rp := range s.m.reports {
+		if rq.Status != "" && rp.Status != rq.Status {
+			continue
+		}
+		matched = append(matched, rp)
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		return byCreatedAt(matched[i].CreatedAt, matched[i].ID, matched[j].CreatedAt, matched[j].ID)
+	})
+
+	return paginate(matched, rq.Limit, rq.Offset), newMetaData(len(matched), rq.Limit, rq.Offset), nil
+}
+
+func (s *MemoryQuestionReportStore) Update(ctx context.Context, report *models.QuestionReport) error {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	stored, ok := s.m.reports[report.ID]
+	if !ok {
+		return ErrNotFound
+	}
+
+	now := time.Now()
+	stored.Status = report.Status
+	stored.ResolutionNote = report.ResolutionNote
+	switch report.Status {
+	case models.ReportStatusResolved, models.ReportStatusRejected:
+		if stored.ResolvedAt == nil {
+			stored.ResolvedAt = &now
+		}
+	default:
+		stored.ResolvedAt = nil
+	}
+	stored.UpdatedAt = now
+	s.m.reports[report.ID] = stored
+
+	report.ResolvedAt = stored.ResolvedAt
+	report.UpdatedAt = stored.UpdatedAt
+
+	return nil
+}
+
+type MemoryCommentStore struct {
+	m *memoryDB
+}
+
+func (s *MemoryCommentStore) Create(ctx context.Context, comment *models.Comment) error {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	now := time.Now()
+	comment.ID = s.m.nextID("question_comments")
+	comment.IsOfficial = false
+	comment.IsHidden = false
+	comment.CreatedAt = now
+	comment.UpdatedAt = now
+	s.m.comments[comment.ID] = *comment
+
+	return nil
+}
+
+func (s *MemoryCommentStore) GetByID(ctx context.Context, id int64) (*models.Comment, error) {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	c, ok := s.m.comments[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	return &c, nil
+}
+
+func (s *MemoryCommentStore) GetByQuestionID(ctx context.Context, questionID int64, cq PaginatedCommentQuery) ([]models.Comment, MetaData, error) {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	var threads, replies []models.Comment
+	for _, c := range s.m.comments {
+		if c.QuestionID != questionID || c.IsHidden {
+			continue
+		}
+		if c.ParentID == nil {
+			threads = append(threads, c)
+		} else {
+			replies = append(replies, c)
+		}
+	}
+
+	sort.Slice(threads, func(i, j int) bool {
+		return byCreatedAt(threads[i].CreatedAt, threads[i].ID, threads[j].CreatedAt, threads[j].ID)
+	})
+	totalItems := len(threads)
+	page := append([]models.Comment{}, paginate(threads, cq.Limit, cq.Offset)...)
+
+	// Jawaban resmi dari editor selalu tampil paling atas di setiap thread
+	sort.Slice(replies, func(i, j int) bool {
+		if replies[i].IsOfficial != replies[j].IsOfficial {
+			return replies[i].IsOfficial
+		}
+		return byCreatedAt(replies[i].CreatedAt, replies[i].ID, replies[j].CreatedAt, replies[j].ID)
+	})
+
+	index := make(map[int64]int, len(page))
+	for i, t := range page {
+		index[t.ID] = i
+	}
+	for _, reply := range replies {
+		if i, ok := index[*reply.ParentID]; ok {
+			page[i].Replies = append(page[i].Replies, reply)
+		}
+	}
+
+	return page, newMetaData(totalItems, cq.Limit, cq.Offset), nil
+}
+
+func (s *MemoryCommentStore) Update(ctx context.Context, comment *models.Comment) error {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	stored, ok := s.m.comments[comment.ID]
+	if !ok {
+		return ErrNotFound
+	}
+
+	stored.IsOfficial = comment.IsOfficial
+	stored.IsHidden = comment.IsHidden
+	stored.UpdatedAt = time.Now()
+	s.m.comments[comment.ID] = stored
+
+	comment.UpdatedAt = stored.UpdatedAt
+
+	return nil
+}
+
+func (s *MemoryCommentStore) Delete(ctx context.Context, id int64) error {
+	s.m.mu.Lock()
+	defer s.m.mu.Unlock()
+
+	if _, ok := s.m.comments[id]; !ok {
+		return ErrNotFound
+	}
+	delete(s.m.comments, id)
+
+	for cid, c := range s.m.comments {
+		if c.ParentID != nil && *c.ParentID == id {
+			delete(s.m.comments, cid)
+		}
+	}
+
+	return nil
+}
@@ -1,43 +1,132 @@
 package store
 
 import (
+	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 )
 
+// QueryParamError dikembalikan Parse jika query parameter tidak bisa dibaca,
+// misal limit yang bukan angka. Handler menjawabnya dengan 400.
+type QueryParamError struct {
+	Param string
+	Value string
+}
+
+func (e *QueryParamError) Error() string {
+	return fmt.Sprintf("query parameter %s harus berupa angka (nilai saat ini: %q)", e.Param, e.Value)
+}
+
+// parseLimitOffset membaca limit dan offset untuk semua query berhalaman.
+// Parameter yang tidak dikirim tidak mengubah nilai default dari pemanggil.
+func parseLimitOffset(qs url.Values, limit, offset *int) error {
+	for _, p := range []struct {
+		name string
+		dst  *int
+	}{{"limit", limit}, {"offset", offset}} {
+		value := qs.Get(p.name)
+		if value == "" {
+			continue
+		}
+
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return &QueryParamError{Param: p.name, Value: value}
+		}
+		*p.dst = n
+	}
+
+	return nil
+}
+
 type PaginatedQuestionQuery struct {
 	Limit  int    `json:"limit" validate:"gte=1,lte=20"`
 	Offset int    `json:"offset" validate:"gte=0"`
 	Search string `json:"search" validate:"max=100"`
+
+	// Status kosong berarti semua status
+	Status string `json:"status" validate:"omitempty,oneof=draft in_review approved published retired"`
 }
 
 func (qq PaginatedQuestionQuery) Parse(r *http.Request) (PaginatedQuestionQuery, error) {
 	qs := r.URL.Query()
 
-	limit := qs.Get("limit")
-	if limit != "" {
-		l, err := strconv.Atoi(limit)
-		if err != nil {
-			return qq, nil
+	if err := parseLimitOffset(qs, &qq.Limit, &qq.Offset); err != nil {
+		return qq, err
+	}
+
+	search := qs.Get("search")
+	if search != "" {
+		qq.Search = search
+	}
+
+	// ?status= atau ?status=all menghapus filter default dari pemanggil
+	if qs.Has("status") {
+		qq.Status = qs.Get("status")
+		if qq.Status == "all" {
+			qq.Status = ""
 		}
+	}
 
-		qq.Limit = l
+	return qq, nil
+}
+
+type PaginatedReportQuery struct {
+	Limit  int `json:"limit" validate:"gte=1,lte=20"`
+	Offset int `json:"offset" validate:"gte=0"`
+
+	// Status kosong berarti semua status
+	Status string `json:"status" validate:"omitempty,oneof=open in_progress resolved rejected"`
+}
+
+func (rq PaginatedReportQuery) Parse(r *http.Request) (PaginatedReportQuery, error) {
+	qs := r.URL.Query()
+
+	if err := parseLimitOffset(qs, &rq.Limit, &rq.Offset); err != nil {
+		return rq, err
 	}
 
-	offset := qs.Get("offset")
-	if offset != "" {
-		l, err := strconv.Atoi(offset)
-		if err != nil {
-			return qq, nil
+	// ?status= atau ?status=all menghapus filter default dari pemanggil
+	if qs.Has("status") {
+		rq.Status = qs.Get("status")
+		if rq.Status == "all" {
+			rq.Status = ""
 		}
+	}
+
+	return rq, nil
+}
 
-		qq.Offset = l
+func newMetaData(totalItems, limit, offset int) MetaData {
+	totalPages := 0
+	if limit > 0 {
+		// Rumus total page: ceil(totalItems / limit)
+		// Cara integer di Go: (total + limit - 1) / limit
+		totalPages = (totalItems + limit - 1) / limit
 	}
 
-	search := qs.Get("search")
-	if search != "" {
-		qq.Search = search
+	currentPage := 1
+	if limit > 0 {
+		currentPage = (offset / limit) + 1
 	}
 
-	return qq, nil
+	return MetaData{
+		CurrentPage: currentPage,
+		Limit:       limit,
+		TotalItems:  totalItems,
+		TotalPages:  totalPages,
+	}
+}
+
+type PaginatedCommentQuery struct {
+	Limit  int `json:"limit" validate:"gte=1,lte=20"`
+	Offset int `json:"offset" validate:"gte=0"`
+}
+
+func (cq PaginatedCommentQuery) Parse(r *http.Request) (PaginatedCommentQuery, error) {
+	qs := r.URL.Query()
+
+	err := parseLimitOffset(qs, &cq.Limit, &cq.Offset)
+	return cq, err
 }
@@ -0,0 +1,75 @@
+package store
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+)
+
+func TestNewMetaData(t *testing.T) {
+	tests := []struct {
+		name                 string
+		total, limit, offset int
+		wantPage, wantPages  int
+	}{
+		{"empty", 0, 20, 0, 1, 0},
+		{"single page", 5, 20, 0, 1, 1},
+		{"exact multiple", 40, 20, 0, 1, 2},
+		{"partial last page", 41, 20, 20, 2, 3},
+		{"last page", 41, 20, 40, 3, 3},
+		{"offset not aligned to limit", 25, 7, 10, 2, 4},
+		{"offset past the end", 25, 10, 30, 4, 3},
+		{"zero limit", 10, 0, 0, 1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newMetaData(tt.total, tt.limit, tt.offset)
+			want := MetaData{CurrentPage: tt.wantPage, Limit: tt.limit, TotalItems: tt.total, TotalPages: tt.wantPages}
+			if got != want {
+				t.Errorf("newMetaData(%d, %d, %d) = %+v, want %+v", tt.total, tt.limit, tt.offset, got, want)
+			}
+		})
+	}
+}
+
+func TestParseLimitOffset(t *testing.T) {
+	tests := []struct {
+		query         string
+		limit, offset int
+		errParam      string
+	}{
+		{"", 20, 0, ""},
+		{"limit=5&offset=10", 5, 10, ""},
+		{"offset=3", 20, 3, ""},
+		{"limit=-1", -1, 0, ""},
+		{"limit=x&offset=10", 20, 0, "limit"},
+		{"limit=5&offset=1.5", 5, 0, "offset"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			qs, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			limit, offset := 20, 0
+			err = parseLimitOffset(qs, &limit, &offset)
+
+			var paramErr *QueryParamError
+			if tt.errParam != "" {
+				if !errors.As(err, &paramErr) || paramErr.Param != tt.errParam {
+					t.Fatalf("error = %v, want a QueryParamError for %s", err, tt.errParam)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if limit != tt.limit || offset != tt.offset {
+				t.Errorf("limit, offset = %d, %d, want %d, %d", limit, offset, tt.limit, tt.offset)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+//go:build integration
+
+package store
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/ReyviRahman/to-backend/internal/dbtest"
+)
+
+var pg *dbtest.Postgres
+
+func TestMain(m *testing.M) {
+	var err error
+	pg, err = dbtest.Start()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	code := m.Run()
+
+	pg.Stop()
+	os.Exit(code)
+}
+
+func TestPostgresStorage(t *testing.T) {
+	testStorage(t, func(t *testing.T) Storage {
+		if err := pg.Reset(); err != nil {
+			t.Fatal(err)
+		}
+		return NewStorage(pg.DB)
+	})
+}
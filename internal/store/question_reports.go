@@ -0,0 +1,168 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/ReyviRahman/to-backend/internal/models"
+)
+
+type QuestionReportStore struct {
+	db *sql.DB
+}
+
+func (s *QuestionReportStore) Create(ctx context.Context, report *models.QuestionReport) (err error) {
+	ctx, op := startQuery(ctx, "reports", "Create")
+	defer func() { op.end(err) }()
+
+	query := `
+		INSERT INTO question_reports (question_id, source, reason, description)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, status, created_at, updated_at
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	return s.db.QueryRowContext(ctx, query,
+		report.QuestionID,
+		report.Source,
+		report.Reason,
+		report.Description,
+	).Scan(&report.ID, &report.Status, &report.CreatedAt, &report.UpdatedAt)
+}
+
+func (s *QuestionReportStore) GetByID(ctx context.Context, id int64) (_ *models.QuestionReport, err error) {
+	ctx, op := startQuery(ctx, "reports", "GetByID")
+	defer func() { op.end(err) }()
+
+	query := `
+		SELECT id, question_id, source, reason, description, status, resolution_note, resolved_at, created_at, updated_at
+		FROM question_reports
+		WHERE id = $1
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	var rp models.QuestionReport
+	err = s.db.QueryRowContext(ctx, query, id).Scan(
+		&rp.ID,
+		&rp.QuestionID,
+		&rp.Source,
+		&rp.Reason,
+		&rp.Description,
+		&rp.Status,
+		&rp.ResolutionNote,
+		&rp.ResolvedAt,
+		&rp.CreatedAt,
+		&rp.UpdatedAt,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &rp, nil
+}
+
+func (s *QuestionReportStore) GetReports(ctx context.Context, rq PaginatedReportQuery) (_ []models.QuestionReport, _ MetaData, err error) {
+	ctx, op := startQuery(ctx, "reports", "GetReports")
+	defer func() { op.end(err) }()
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	var totalItems int
+	countQuery := `SELECT COUNT(id) FROM question_reports WHERE ($1 = '' OR status = $1)`
+
+	err = s.db.QueryRowContext(ctx, countQuery, rq.Status).Scan(&totalItems)
+	if err != nil {
+		return nil, MetaData{}, err
+	}
+
+	// Laporan paling lama tampil lebih dulu supaya antrean triase adil
+	query := `
+		SELECT id, question_id, source, reason, description, status, resolution_note, resolved_at, created_at, updated_at
+		FROM question_reports
+		WHERE ($1 = '' OR status = $1)
+		ORDER BY created_at ASC
+		LIMIT $2 OFFSET $3
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, rq.Status, rq.Limit, rq.Offset)
+	if err != nil {
+		return nil, MetaData{}, err
+	}
+	defer rows.Close()
+
+	var reports []models.QuestionReport
+	for rows.Next() {
+		var rp models.QuestionReport
+		err := rows.Scan(
+			&rp.ID,
+			&rp.QuestionID,
+			&rp.Source,
+			&rp.Reason,
+			&rp.Description,
+			&rp.Status,
+			&rp.ResolutionNote,
+			&rp.ResolvedAt,
+			&rp.CreatedAt,
+			&rp.UpdatedAt,
+		)
+		if err != nil {
+			return nil, MetaData{}, err
+		}
+		reports = append(reports, rp)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, MetaData{}, err
+	}
+
+	op.setRows(len(reports))
+
+	return reports, newMetaData(totalItems, rq.Limit, rq.Offset), nil
+}
+
+func (s *QuestionReportStore) Update(ctx context.Context, report *models.QuestionReport) (err error) {
+	ctx, op := startQuery(ctx, "reports", "Update")
+	defer func() { op.end(err) }()
+
+	// resolved_at diisi saat laporan ditutup dan dikosongkan lagi jika dibuka ulang
+	query := `
+		UPDATE question_reports
+		SET status = $1,
+			resolution_note = $2,
+			resolved_at = CASE WHEN $1 IN ('resolved', 'rejected') THEN COALESCE(resolved_at, NOW()) ELSE NULL END,
+			updated_at = NOW()
+		WHERE id = $3
+		RETURNING resolved_at, updated_at
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	err = s.db.QueryRowContext(ctx, query,
+		report.Status,
+		report.ResolutionNote,
+		report.ID,
+	).Scan(&report.ResolvedAt, &report.UpdatedAt)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrNotFound
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
@@ -13,26 +13,69 @@ type QuestionStore struct {
 	db *sql.DB
 }
 
-func (s *QuestionStore) Create(ctx context.Context, question *models.Question) error {
+func (s *QuestionStore) Create(ctx context.Context, question *models.Question) (err error) {
+	ctx, op := startQuery(ctx, "questions", "Create")
+	defer func() { op.end(err) }()
+
 	query := `
-		INSERT INTO questions (category, question_text, options, explanation)
-		VALUES ($1, $2, $3, $4)
-		RETURNING id, created_at, updated_at
+		INSERT INTO questions (category, question_text, question_text_html, options, explanation, explanation_html)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, status, created_at, updated_at
 	`
 
 	ctx, cancel := context.WithTimeout(ctx, time.Second*5)
 	defer cancel()
 
-	err := s.db.QueryRowContext(ctx, query,
+	err = s.db.QueryRowContext(ctx, query,
 		question.Category,
 		question.QuestionText,
+		question.QuestionTextHTML,
 		question.Options,
 		question.Explanation,
-	).Scan(&question.ID, &question.CreatedAt, &question.UpdatedAt)
+		question.ExplanationHTML,
+	).Scan(&question.ID, &question.Status, &question.CreatedAt, &question.UpdatedAt)
 
 	return err
 }
 
+func (s *QuestionStore) GetByID(ctx context.Context, id int64) (_ *models.Question, err error) {
+	ctx, op := startQuery(ctx, "questions", "GetByID")
+	defer func() { op.end(err) }()
+
+	query := `
+		SELECT id, category, question_text, question_text_html, options, explanation, explanation_html, status, created_at, updated_at
+		FROM questions
+		WHERE id = $1
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	var q models.Question
+	err = s.db.QueryRowContext(ctx, query, id).Scan(
+		&q.ID,
+		&q.Category,
+		&q.QuestionText,
+		&q.QuestionTextHTML,
+		&q.Options,
+		&q.Explanation,
+		&q.ExplanationHTML,
+		&q.Status,
+		&q.CreatedAt,
+		&q.UpdatedAt,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return nil, ErrNotFound
+		default:
+			return nil, err
+		}
+	}
+
+	return &q, nil
+}
+
 type MetaData struct {
 	CurrentPage int `json:"current_page"`
 	Limit       int `json:"limit"`
@@ -40,31 +83,39 @@ type MetaData struct {
 	TotalPages  int `json:"total_pages"`
 }
 
-func (s *QuestionStore) GetQuestions(ctx context.Context, qq PaginatedQuestionQuery) ([]models.Question, MetaData, error) {
+func (s *QuestionStore) GetQuestions(ctx context.Context, qq PaginatedQuestionQuery) (_ []models.Question, _ MetaData, err error) {
+	ctx, op := startQuery(ctx, "questions", "GetQuestions")
+	defer func() { op.end(err) }()
+
 	ctx, cancel := context.WithTimeout(ctx, time.Second*5)
 	defer cancel()
 
 	// 1. Query Pertama: Hitung Total Data (Tanpa Limit/Offset)
 	var totalItems int
-	countQuery := `SELECT COUNT(id) FROM questions`
+	// Filter di countQuery harus sama dengan query data di bawah
+	countQuery := `
+		SELECT COUNT(id)
+		FROM questions
+		WHERE ($1 = '' OR question_text ILIKE '%' || $1 || '%')
+			AND ($2 = '' OR status = $2)
+	`
 
-	// Jika nanti ada filter (misal WHERE category = 'TIU'),
-	// pastikan countQuery juga pakai WHERE yang sama.
-	err := s.db.QueryRowContext(ctx, countQuery).Scan(&totalItems)
+	err = s.db.QueryRowContext(ctx, countQuery, qq.Search, qq.Status).Scan(&totalItems)
 	if err != nil {
 		return nil, MetaData{}, err
 	}
 
 	// 2. Query Kedua: Ambil Data Sebenarnya (Pakai Limit/Offset)
 	query := `
-        SELECT id, category, question_text, options, explanation, created_at, updated_at
+        SELECT id, category, question_text, question_text_html, options, explanation, explanation_html, status, created_at, updated_at
         FROM questions
 				WHERE ($1 = '' OR question_text ILIKE '%' || $1 || '%')
+					AND ($2 = '' OR status = $2)
         ORDER BY created_at DESC
-        LIMIT $2 OFFSET $3
+        LIMIT $3 OFFSET $4
     `
 
-	rows, err := s.db.QueryContext(ctx, query, qq.Search, qq.Limit, qq.Offset)
+	rows, err := s.db.QueryContext(ctx, query, qq.Search, qq.Status, qq.Limit, qq.Offset)
 	if err != nil {
 		return nil, MetaData{}, err
 	}
@@ -77,8 +128,11 @@ func (s *QuestionStore) GetQuestions(ctx context.Context, qq PaginatedQuestionQu
 			&q.ID,
 			&q.Category,
 			&q.QuestionText,
+			&q.QuestionTextHTML,
 			&q.Options,
 			&q.Explanation,
+			&q.ExplanationHTML,
+			&q.Status,
 			&q.CreatedAt,
 			&q.UpdatedAt,
 		)
@@ -92,61 +146,93 @@ func (s *QuestionStore) GetQuestions(ctx context.Context, qq PaginatedQuestionQu
 		return nil, MetaData{}, err
 	}
 
-	// 3. Hitung Kalkulasi Metadata
-	totalPages := 0
-	if qq.Limit > 0 {
-		// Rumus total page: ceil(totalItems / limit)
-		// Cara integer di Go: (total + limit - 1) / limit
-		totalPages = (totalItems + qq.Limit - 1) / qq.Limit
-	}
-
-	currentPage := 1
-	if qq.Limit > 0 {
-		currentPage = (qq.Offset / qq.Limit) + 1
-	}
+	op.setRows(len(questions))
 
-	meta := MetaData{
-		CurrentPage: currentPage,
-		Limit:       qq.Limit,
-		TotalItems:  totalItems,
-		TotalPages:  totalPages,
-	}
+	// 3. Hitung Kalkulasi Metadata
+	meta := newMetaData(totalItems, qq.Limit, qq.Offset)
 
 	return questions, meta, nil
 }
 
-func (s *QuestionStore) Update(ctx context.Context, question *models.Question) error {
+// Update mengganti isi soal sekaligus mengubah statusnya menjadi status.
+// Sama seperti UpdateStatus, perubahan hanya berhasil jika status di database
+// masih sama dengan question.Status.
+func (s *QuestionStore) Update(ctx context.Context, question *models.Question, status string) (err error) {
+	ctx, op := startQuery(ctx, "questions", "Update")
+	defer func() { op.end(err) }()
+
 	query := `
 		UPDATE questions
-		SET category = $1, question_text = $2, options = $3, explanation = $4, updated_at = NOW()
-		WHERE id = $5
+		SET category = $1, question_text = $2, question_text_html = $3, options = $4,
+			explanation = $5, explanation_html = $6, status = $7, updated_at = NOW()
+		WHERE id = $8 AND status = $9
 		RETURNING updated_at
 	`
 
 	ctx, cancel := context.WithTimeout(ctx, time.Second*5)
 	defer cancel()
 
-	err := s.db.QueryRowContext(ctx, query,
+	err = s.db.QueryRowContext(ctx, query,
 		question.Category,
 		question.QuestionText,
+		question.QuestionTextHTML,
 		question.Options,
 		question.Explanation,
+		question.ExplanationHTML,
+		status,
 		question.ID,
+		question.Status,
 	).Scan(&question.UpdatedAt)
 
 	if err != nil {
 		switch {
-		case err == sql.ErrNoRows:
-			return errors.New("data tidak ditemukan")
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrConflict
 		default:
 			return err
 		}
 	}
 
+	question.Status = status
+
 	return nil
 }
 
-func (s *QuestionStore) Delete(ctx context.Context, id int64) error {
+// UpdateStatus hanya berhasil jika status di database masih sama dengan
+// question.Status, supaya dua editor tidak saling menimpa perubahan status.
+func (s *QuestionStore) UpdateStatus(ctx context.Context, question *models.Question, status string) (err error) {
+	ctx, op := startQuery(ctx, "questions", "UpdateStatus")
+	defer func() { op.end(err) }()
+
+	query := `
+		UPDATE questions
+		SET status = $1, updated_at = NOW()
+		WHERE id = $2 AND status = $3
+		RETURNING updated_at
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	err = s.db.QueryRowContext(ctx, query, status, question.ID, question.Status).Scan(&question.UpdatedAt)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return ErrConflict
+		default:
+			return err
+		}
+	}
+
+	question.Status = status
+
+	return nil
+}
+
+func (s *QuestionStore) Delete(ctx context.Context, id int64) (err error) {
+	ctx, op := startQuery(ctx, "questions", "Delete")
+	defer func() { op.end(err) }()
+
 	query := `DELETE FROM questions WHERE id = $1`
 
 	ctx, cancel := context.WithTimeout(ctx, time.Second*5)
@@ -162,8 +248,9 @@ func (s *QuestionStore) Delete(ctx context.Context, id int64) error {
 		return err
 	}
 
+	op.setRows(int(rows))
 	if rows == 0 {
-		return errors.New("data tidak ditemukan")
+		return ErrNotFound
 	}
 
 	return nil
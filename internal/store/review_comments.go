@@ -0,0 +1,66 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/ReyviRahman/to-backend/internal/models"
+)
+
+type ReviewCommentStore struct {
+	db *sql.DB
+}
+
+func (s *ReviewCommentStore) Create(ctx context.Context, comment *models.ReviewComment) (err error) {
+	ctx, op := startQuery(ctx, "review_comments", "Create")
+	defer func() { op.end(err) }()
+
+	query := `
+		INSERT INTO question_review_comments (question_id, field, body)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	return s.db.QueryRowContext(ctx, query,
+		comment.QuestionID,
+		comment.Field,
+		comment.Body,
+	).Scan(&comment.ID, &comment.CreatedAt)
+}
+
+func (s *ReviewCommentStore) GetByQuestionID(ctx context.Context, questionID int64) (_ []models.ReviewComment, err error) {
+	ctx, op := startQuery(ctx, "review_comments", "GetByQuestionID")
+	defer func() { op.end(err) }()
+
+	query := `
+		SELECT id, question_id, field, body, created_at
+		FROM question_review_comments
+		WHERE question_id = $1
+		ORDER BY created_at ASC
+	`
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	rows, err := s.db.QueryContext(ctx, query, questionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	comments := []models.ReviewComment{}
+	for rows.Next() {
+		var c models.ReviewComment
+		if err := rows.Scan(&c.ID, &c.QuestionID, &c.Field, &c.Body, &c.CreatedAt); err != nil {
+			return nil, err
+		}
+		comments = append(comments, c)
+	}
+	op.setRows(len(comments))
+
+	return comments, rows.Err()
+}
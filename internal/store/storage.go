@@ -3,21 +3,49 @@ package store
 import (
 	"context"
 	"database/sql"
+	"errors"
 
 	"github.com/ReyviRahman/to-backend/internal/models"
 )
 
+var (
+	ErrNotFound = errors.New("data tidak ditemukan")
+	ErrConflict = errors.New("data telah diubah oleh proses lain")
+)
+
 type Storage struct {
 	Questions interface {
 		Create(ctx context.Context, question *models.Question) error
+		GetByID(ctx context.Context, id int64) (*models.Question, error)
 		GetQuestions(ctx context.Context, qq PaginatedQuestionQuery) ([]models.Question, MetaData, error)
-		Update(ctx context.Context, question *models.Question) error
+		Update(ctx context.Context, question *models.Question, status string) error
+		UpdateStatus(ctx context.Context, question *models.Question, status string) error
+		Delete(ctx context.Context, id int64) error
+	}
+	ReviewComments interface {
+		Create(ctx context.Context, comment *models.ReviewComment) error
+		GetByQuestionID(ctx context.Context, questionID int64) ([]models.ReviewComment, error)
+	}
+	Reports interface {
+		Create(ctx context.Context, report *models.QuestionReport) error
+		GetByID(ctx context.Context, id int64) (*models.QuestionReport, error)
+		GetReports(ctx context.Context, rq PaginatedReportQuery) ([]models.QuestionReport, MetaData, error)
+		Update(ctx context.Context, report *models.QuestionReport) error
+	}
+	Comments interface {
+		Create(ctx context.Context, comment *models.Comment) error
+		GetByID(ctx context.Context, id int64) (*models.Comment, error)
+		GetByQuestionID(ctx context.Context, questionID int64, cq PaginatedCommentQuery) ([]models.Comment, MetaData, error)
+		Update(ctx context.Context, comment *models.Comment) error
 		Delete(ctx context.Context, id int64) error
 	}
 }
 
 func NewStorage(db *sql.DB) Storage {
 	return Storage{
-		Questions: &QuestionStore{db},
+		Questions:      &QuestionStore{db},
+		ReviewComments: &ReviewCommentStore{db},
+		Reports:        &QuestionReportStore{db},
+		Comments:       &CommentStore{db},
 	}
 }
@@ -0,0 +1,419 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/ReyviRahman/to-backend/internal/models"
+)
+
+// testStorage menjalankan skenario yang sama terhadap implementasi Storage
+// apa pun, supaya store memori (dipakai test handler) tetap berperilaku sama
+// dengan store Postgres. newStorage harus mengembalikan storage yang kosong.
+func testStorage(t *testing.T, newStorage func(t *testing.T) Storage) {
+	t.Run("Questions", func(t *testing.T) { testQuestionStore(t, newStorage(t)) })
+	t.Run("QuestionsList", func(t *testing.T) { testQuestionList(t, newStorage(t)) })
+	t.Run("QuestionsDeleteCascade", func(t *testing.T) { testQuestionDeleteCascade(t, newStorage(t)) })
+	t.Run("ReviewComments", func(t *testing.T) { testReviewCommentStore(t, newStorage(t)) })
+	t.Run("Reports", func(t *testing.T) { testReportStore(t, newStorage(t)) })
+	t.Run("Comments", func(t *testing.T) { testCommentStore(t, newStorage(t)) })
+}
+
+func TestMemoryStorage(t *testing.T) {
+	testStorage(t, func(t *testing.T) Storage { return NewMemoryStorage() })
+}
+
+func createQuestion(t *testing.T, s Storage, text string) *models.Question {
+	t.Helper()
+
+	q := &models.Question{
+		Category:         "TIU",
+		QuestionText:     text,
+		QuestionTextHTML: "<p>" + text + "</p>",
+		Options: models.QuestionOptions{
+			{Code: "A", Text: "satu", TextHTML: "<p>satu</p>", Score: 5},
+			{Code: "B", Text: "dua", TextHTML: "<p>dua</p>", Score: 0},
+		},
+		Explanation:     "penjelasan",
+		ExplanationHTML: "<p>penjelasan</p>",
+	}
+	if err := s.Questions.Create(t.Context(), q); err != nil {
+		t.Fatalf("creating question: %v", err)
+	}
+
+	return q
+}
+
+func testQuestionStore(t *testing.T, s Storage) {
+	ctx := t.Context()
+
+	q := createQuestion(t, s, "Soal pertama")
+	if q.ID == 0 || q.Status != models.QuestionStatusDraft || q.CreatedAt.IsZero() {
+		t.Fatalf("created question = %+v, want id, draft status and timestamps", q)
+	}
+
+	got, err := s.Questions.GetByID(ctx, q.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.QuestionText != q.QuestionText || len(got.Options) != 2 || got.Options[0].TextHTML != "<p>satu</p>" {
+		t.Errorf("GetByID = %+v, want the created question", got)
+	}
+
+	if _, err := s.Questions.GetByID(ctx, q.ID+100); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetByID unknown id error = %v, want ErrNotFound", err)
+	}
+
+	q.Category = "TKP"
+	if err := s.Questions.Update(ctx, q, models.QuestionStatusDraft); err != nil {
+		t.Fatal(err)
+	}
+	if q.Status != models.QuestionStatusDraft {
+		t.Errorf("status after Update = %q, want draft", q.Status)
+	}
+
+	// Update dengan status lama (misal soal sudah di-approve editor lain) ditolak
+	outdated := *q
+	outdated.Status = models.QuestionStatusApproved
+	if err := s.Questions.Update(ctx, &outdated, models.QuestionStatusDraft); !errors.Is(err, ErrConflict) {
+		t.Errorf("Update with stale status error = %v, want ErrConflict", err)
+	}
+
+	missing := *q
+	missing.ID = q.ID + 100
+	if err := s.Questions.Update(ctx, &missing, models.QuestionStatusDraft); !errors.Is(err, ErrConflict) {
+		t.Errorf("Update unknown id error = %v, want ErrConflict", err)
+	}
+
+	// Dua editor membaca status yang sama, hanya yang pertama berhasil
+	stale := *q
+	if err := s.Questions.UpdateStatus(ctx, q, models.QuestionStatusInReview); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Questions.UpdateStatus(ctx, &stale, models.QuestionStatusInReview); !errors.Is(err, ErrConflict) {
+		t.Errorf("UpdateStatus with stale status error = %v, want ErrConflict", err)
+	}
+
+	got, err = s.Questions.GetByID(ctx, q.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Category != "TKP" || got.Status != models.QuestionStatusInReview {
+		t.Errorf("stored question = %+v, want TKP in_review", got)
+	}
+
+	if err := s.Questions.Delete(ctx, q.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Questions.Delete(ctx, q.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("second Delete error = %v, want ErrNotFound", err)
+	}
+}
+
+func testQuestionList(t *testing.T, s Storage) {
+	ctx := t.Context()
+
+	for i := 1; i <= 5; i++ {
+		q := createQuestion(t, s, fmt.Sprintf("Soal deret nomor %d", i))
+		if i%2 == 0 {
+			if err := s.Questions.UpdateStatus(ctx, q, models.QuestionStatusInReview); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	createQuestion(t, s, "Soal pancasila")
+
+	tests := []struct {
+		name  string
+		query PaginatedQuestionQuery
+		want  []string
+		meta  MetaData
+	}{
+		{
+			name:  "newest first",
+			query: PaginatedQuestionQuery{Limit: 2, Offset: 0},
+			want:  []string{"Soal pancasila", "Soal deret nomor 5"},
+			meta:  MetaData{CurrentPage: 1, Limit: 2, TotalItems: 6, TotalPages: 3},
+		},
+		{
+			name:  "last page",
+			query: PaginatedQuestionQuery{Limit: 4, Offset: 4},
+			want:  []string{"Soal deret nomor 2", "Soal deret nomor 1"},
+			meta:  MetaData{CurrentPage: 2, Limit: 4, TotalItems: 6, TotalPages: 2},
+		},
+		{
+			name:  "search is case-insensitive",
+			query: PaginatedQuestionQuery{Limit: 20, Search: "DERET"},
+			want:  []string{"Soal deret nomor 5", "Soal deret nomor 4", "Soal deret nomor 3", "Soal deret nomor 2", "Soal deret nomor 1"},
+			meta:  MetaData{CurrentPage: 1, Limit: 20, TotalItems: 5, TotalPages: 1},
+		},
+		{
+			name:  "status filter",
+			query: PaginatedQuestionQuery{Limit: 20, Status: models.QuestionStatusInReview},
+			want:  []string{"Soal deret nomor 4", "Soal deret nomor 2"},
+			meta:  MetaData{CurrentPage: 1, Limit: 20, TotalItems: 2, TotalPages: 1},
+		},
+		{
+			name:  "no match",
+			query: PaginatedQuestionQuery{Limit: 20, Search: "tidak ada"},
+			want:  nil,
+			meta:  MetaData{CurrentPage: 1, Limit: 20, TotalItems: 0, TotalPages: 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			questions, meta, err := s.Questions.GetQuestions(ctx, tt.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for _, q := range questions {
+				got = append(got, q.QuestionText)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("questions = %q, want %q", got, tt.want)
+			}
+			if meta != tt.meta {
+				t.Errorf("meta = %+v, want %+v", meta, tt.meta)
+			}
+		})
+	}
+}
+
+func testQuestionDeleteCascade(t *testing.T, s Storage) {
+	ctx := t.Context()
+	q := createQuestion(t, s, "Soal yang akan dihapus")
+
+	report := &models.QuestionReport{QuestionID: q.ID, Source: "practice", Reason: "typo"}
+	if err := s.Reports.Create(ctx, report); err != nil {
+		t.Fatal(err)
+	}
+	comment := &models.Comment{QuestionID: q.ID, Body: "komentar"}
+	if err := s.Comments.Create(ctx, comment); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.ReviewComments.Create(ctx, &models.ReviewComment{QuestionID: q.ID, Field: "options", Body: "x"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := s.Questions.Delete(ctx, q.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.Reports.GetByID(ctx, report.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("report after question delete: error = %v, want ErrNotFound", err)
+	}
+	if _, err := s.Comments.GetByID(ctx, comment.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("comment after question delete: error = %v, want ErrNotFound", err)
+	}
+	reviews, err := s.ReviewComments.GetByQuestionID(ctx, q.ID)
+	if err != nil || len(reviews) != 0 {
+		t.Errorf("review comments after question delete = %v (error %v), want none", reviews, err)
+	}
+}
+
+func testReviewCommentStore(t *testing.T, s Storage) {
+	ctx := t.Context()
+	q := createQuestion(t, s, "Soal yang direview")
+
+	empty, err := s.ReviewComments.GetByQuestionID(ctx, q.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if empty == nil || len(empty) != 0 {
+		t.Errorf("GetByQuestionID with no comments = %#v, want an empty non-nil slice", empty)
+	}
+
+	for _, field := range []string{"question_text", "explanation"} {
+		c := &models.ReviewComment{QuestionID: q.ID, Field: field, Body: "perbaiki"}
+		if err := s.ReviewComments.Create(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+		if c.ID == 0 || c.CreatedAt.IsZero() {
+			t.Errorf("created review comment = %+v, want id and created_at", c)
+		}
+	}
+
+	comments, err := s.ReviewComments.GetByQuestionID(ctx, q.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(comments) != 2 || comments[0].Field != "question_text" || comments[1].Field != "explanation" {
+		t.Errorf("review comments = %+v, want oldest first", comments)
+	}
+}
+
+func testReportStore(t *testing.T, s Storage) {
+	ctx := t.Context()
+	q := createQuestion(t, s, "Soal yang dilaporkan")
+
+	var reports []*models.QuestionReport
+	for _, reason := range []string{"wrong_key", "typo", "unclear_image"} {
+		rp := &models.QuestionReport{QuestionID: q.ID, Source: "practice", Reason: reason}
+		if err := s.Reports.Create(ctx, rp); err != nil {
+			t.Fatal(err)
+		}
+		if rp.Status != models.ReportStatusOpen {
+			t.Errorf("new report status = %q, want open", rp.Status)
+		}
+		reports = append(reports, rp)
+	}
+
+	first := reports[0]
+	first.Status = models.ReportStatusResolved
+	first.ResolutionNote = "kunci diperbaiki"
+	if err := s.Reports.Update(ctx, first); err != nil {
+		t.Fatal(err)
+	}
+	if first.ResolvedAt == nil {
+		t.Fatal("resolved_at is not set after resolving")
+	}
+	resolvedAt := *first.ResolvedAt
+
+	// Menolak laporan yang sudah ditutup tidak mengubah waktu penutupan
+	first.Status = models.ReportStatusRejected
+	if err := s.Reports.Update(ctx, first); err != nil {
+		t.Fatal(err)
+	}
+	if first.ResolvedAt == nil || !first.ResolvedAt.Equal(resolvedAt) {
+		t.Errorf("resolved_at = %v, want unchanged %v", first.ResolvedAt, resolvedAt)
+	}
+
+	first.Status = models.ReportStatusOpen
+	if err := s.Reports.Update(ctx, first); err != nil {
+		t.Fatal(err)
+	}
+	if first.ResolvedAt != nil {
+		t.Errorf("resolved_at after reopening = %v, want nil", first.ResolvedAt)
+	}
+
+	first.Status = models.ReportStatusResolved
+	if err := s.Reports.Update(ctx, first); err != nil {
+		t.Fatal(err)
+	}
+
+	open, meta, err := s.Reports.GetReports(ctx, PaginatedReportQuery{Limit: 1, Offset: 1, Status: models.ReportStatusOpen})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(open) != 1 || open[0].ID != reports[2].ID {
+		t.Errorf("second open report = %+v, want report %d", open, reports[2].ID)
+	}
+	if want := (MetaData{CurrentPage: 2, Limit: 1, TotalItems: 2, TotalPages: 2}); meta != want {
+		t.Errorf("meta = %+v, want %+v", meta, want)
+	}
+
+	all, meta, err := s.Reports.GetReports(ctx, PaginatedReportQuery{Limit: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 3 || meta.TotalItems != 3 || all[0].ID != first.ID {
+		t.Errorf("all reports = %+v, want 3 reports oldest first", all)
+	}
+
+	got, err := s.Reports.GetByID(ctx, first.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Status != models.ReportStatusResolved || got.ResolutionNote != "kunci diperbaiki" {
+		t.Errorf("stored report = %+v", got)
+	}
+
+	missing := &models.QuestionReport{ID: first.ID + 100, Status: models.ReportStatusOpen}
+	if err := s.Reports.Update(ctx, missing); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Update unknown id error = %v, want ErrNotFound", err)
+	}
+	if _, err := s.Reports.GetByID(ctx, missing.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetByID unknown id error = %v, want ErrNotFound", err)
+	}
+}
+
+func testCommentStore(t *testing.T, s Storage) {
+	ctx := t.Context()
+	q := createQuestion(t, s, "Soal yang didiskusikan")
+
+	create := func(body string, parent *models.Comment) *models.Comment {
+		t.Helper()
+		c := &models.Comment{QuestionID: q.ID, Body: body, BodyHTML: "<p>" + body + "</p>"}
+		if parent != nil {
+			c.ParentID = &parent.ID
+		}
+		if err := s.Comments.Create(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+
+	threads, meta, err := s.Comments.GetByQuestionID(ctx, q.ID, PaginatedCommentQuery{Limit: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if threads == nil || len(threads) != 0 || meta.TotalItems != 0 {
+		t.Errorf("comments on a new question = %#v (meta %+v), want an empty non-nil slice", threads, meta)
+	}
+
+	first := create("pertama", nil)
+	hidden := create("disembunyikan", nil)
+	third := create("ketiga", nil)
+	replyA := create("balasan a", first)
+	replyB := create("balasan b", first)
+	hiddenReply := create("balasan tersembunyi", first)
+	create("balasan ketiga", third)
+
+	replyB.IsOfficial = true
+	if err := s.Comments.Update(ctx, replyB); err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range []*models.Comment{hidden, hiddenReply} {
+		c.IsHidden = true
+		if err := s.Comments.Update(ctx, c); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	threads, meta, err = s.Comments.GetByQuestionID(ctx, q.ID, PaginatedCommentQuery{Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(threads) != 1 || threads[0].ID != first.ID {
+		t.Fatalf("first page = %+v, want comment %d", threads, first.ID)
+	}
+	if want := (MetaData{CurrentPage: 1, Limit: 1, TotalItems: 2, TotalPages: 2}); meta != want {
+		t.Errorf("meta = %+v, want %+v (hidden comments are not counted)", meta, want)
+	}
+
+	if threads[0].BodyHTML != "<p>pertama</p>" {
+		t.Errorf("body_html = %q, want it stored with the comment", threads[0].BodyHTML)
+	}
+
+	replies := threads[0].Replies
+	if len(replies) != 2 || replies[0].ID != replyB.ID || replies[1].ID != replyA.ID {
+		t.Errorf("replies = %+v, want official reply %d then %d", replies, replyB.ID, replyA.ID)
+	}
+
+	threads, _, err = s.Comments.GetByQuestionID(ctx, q.ID, PaginatedCommentQuery{Limit: 1, Offset: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(threads) != 1 || threads[0].ID != third.ID || len(threads[0].Replies) != 1 {
+		t.Errorf("second page = %+v, want comment %d with one reply", threads, third.ID)
+	}
+
+	if err := s.Comments.Delete(ctx, first.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Comments.GetByID(ctx, replyA.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("reply after deleting its parent: error = %v, want ErrNotFound", err)
+	}
+	if err := s.Comments.Delete(ctx, first.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("second Delete error = %v, want ErrNotFound", err)
+	}
+
+	missing := &models.Comment{ID: first.ID + 100}
+	if err := s.Comments.Update(ctx, missing); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Update unknown id error = %v, want ErrNotFound", err)
+	}
+}